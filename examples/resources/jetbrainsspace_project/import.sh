@@ -0,0 +1,6 @@
+# By key
+terraform import jetbrainsspace_project.example key:ABC
+terraform import jetbrainsspace_project.example ABC
+
+# By ID
+terraform import jetbrainsspace_project.example 2t6ZbS3YHBfR
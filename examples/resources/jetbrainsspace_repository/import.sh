@@ -0,0 +1,5 @@
+# By project key, as shown in clone URLs
+terraform import jetbrainsspace_repository.example ABC/my-repo
+
+# By project ID
+terraform import jetbrainsspace_repository.example 2t6ZbS3YHBfR/my-repo
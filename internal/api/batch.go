@@ -0,0 +1,117 @@
+package jetbrains_space_api_client_go
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const defaultPageSize = 100
+
+// Batch is the envelope Space wraps around every list response. Next is the
+// opaque offset to send back as $skip to get the following page.
+type Batch[T any] struct {
+	Next       string `json:"next"`
+	TotalCount *int   `json:"totalCount"`
+	Data       []T    `json:"data"`
+}
+
+// BatchIterator walks a Space list endpoint one page at a time.
+//
+//	it := newBatchIterator[Project](c, "/api/http/projects", nil)
+//	for it.NextPage(ctx) {
+//		for _, p := range it.Page() { ... }
+//	}
+//	if err := it.Err(); err != nil { ... }
+type BatchIterator[T any] struct {
+	client  *Client
+	path    string
+	query   url.Values
+	skip    string
+	fetched int
+	done    bool
+	page    []T
+	err     error
+}
+
+func newBatchIterator[T any](c *Client, path string, query url.Values) *BatchIterator[T] {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = append([]string(nil), v...)
+	}
+	return &BatchIterator[T]{client: c, path: path, query: q}
+}
+
+// NextPage fetches the next batch. It returns false once the listing is
+// exhausted or a request fails; check Err to tell the two apart.
+func (it *BatchIterator[T]) NextPage(ctx context.Context) bool {
+	if it.done {
+		return false
+	}
+
+	pageSize := it.client.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	it.query.Set("$top", strconv.Itoa(pageSize))
+	if it.skip != "" {
+		it.query.Set("$skip", it.skip)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", it.client.endpoint(it.path, it.query), nil)
+	if err != nil {
+		it.err, it.done = err, true
+		return false
+	}
+	body, err := it.client.doRequest(req)
+	if err != nil {
+		it.err, it.done = err, true
+		return false
+	}
+
+	var batch Batch[T]
+	if err := json.Unmarshal(body, &batch); err != nil {
+		it.err, it.done = err, true
+		return false
+	}
+
+	it.page = batch.Data
+	it.fetched += len(batch.Data)
+
+	// Space signals the end either with an empty page, a next offset that
+	// doesn't move, or by reaching totalCount.
+	switch {
+	case len(batch.Data) == 0, batch.Next == "", batch.Next == it.skip:
+		it.done = true
+	case batch.TotalCount != nil && it.fetched >= *batch.TotalCount:
+		it.done = true
+	}
+	it.skip = batch.Next
+
+	return len(batch.Data) > 0
+}
+
+// Page returns the batch fetched by the last successful NextPage call.
+func (it *BatchIterator[T]) Page() []T {
+	return it.page
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *BatchIterator[T]) Err() error {
+	return it.err
+}
+
+// listAll drains every page of a list endpoint.
+func listAll[T any](ctx context.Context, c *Client, path string, query url.Values) ([]T, error) {
+	var all []T
+	it := newBatchIterator[T](c, path, query)
+	for it.NextPage(ctx) {
+		all = append(all, it.Page()...)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,165 @@
+package jetbrains_space_api_client_go_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	space "terraform-provider-jetbrains-space/internal/api"
+	"terraform-provider-jetbrains-space/internal/spacetest"
+)
+
+func TestGetProjectsFollowsPages(t *testing.T) {
+	s := spacetest.NewServer()
+	defer s.Close()
+	for i := 1; i <= 5; i++ {
+		s.AddProject(fmt.Sprintf("P%d", i), fmt.Sprintf("Project %d", i))
+	}
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.PageSize = 2
+
+	projects, err := c.GetProjects(context.Background(), space.ProjectFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for _, p := range projects.AllProjects {
+		keys = append(keys, p.Key.Key)
+	}
+	if got, want := strings.Join(keys, ","), "P1,P2,P3,P4,P5"; got != want {
+		t.Errorf("got projects %s, want %s", got, want)
+	}
+
+	var skips []string
+	for _, r := range s.Requests() {
+		if queryValue(r.Query, "$top") != "2" {
+			t.Errorf("request %s?%s does not ask for pages of 2", r.Path, r.Query)
+		}
+		skips = append(skips, queryValue(r.Query, "$skip"))
+	}
+	// The third page reaches totalCount, so no empty fourth page is fetched.
+	if got, want := strings.Join(skips, ","), ",2,4"; got != want {
+		t.Errorf("got $skip sequence %q, want %q", got, want)
+	}
+}
+
+func TestBatchIteratorStops(t *testing.T) {
+	cases := []struct {
+		name string
+		// pages maps the $skip of a request to the batch returned for it.
+		pages        map[string]string
+		wantProjects int
+		wantRequests int
+	}{
+		{
+			name: "next does not move",
+			pages: map[string]string{
+				"":  `{"next":"2","data":[{"id":"1"},{"id":"2"}]}`,
+				"2": `{"next":"2","data":[{"id":"3"},{"id":"4"}]}`,
+			},
+			wantProjects: 4,
+			wantRequests: 2,
+		},
+		{
+			name: "totalCount reached",
+			pages: map[string]string{
+				"":  `{"next":"2","totalCount":3,"data":[{"id":"1"},{"id":"2"}]}`,
+				"2": `{"next":"4","totalCount":3,"data":[{"id":"3"}]}`,
+			},
+			wantProjects: 3,
+			wantRequests: 2,
+		},
+		{
+			name: "empty page",
+			pages: map[string]string{
+				"":  `{"next":"2","data":[{"id":"1"},{"id":"2"}]}`,
+				"2": `{"next":"4","data":[]}`,
+			},
+			wantProjects: 2,
+			wantRequests: 2,
+		},
+		{
+			name: "no next offset",
+			pages: map[string]string{
+				"": `{"next":"","data":[{"id":"1"},{"id":"2"}]}`,
+			},
+			wantProjects: 2,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				page, ok := tc.pages[r.URL.Query().Get("$skip")]
+				if !ok {
+					t.Errorf("unexpected request for $skip=%q", r.URL.Query().Get("$skip"))
+					http.NotFound(w, r)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, page)
+			}))
+			defer server.Close()
+
+			c, err := space.NewClient(server.URL, "token")
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.PageSize = 2
+
+			projects, err := c.GetProjects(context.Background(), space.ProjectFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(projects.AllProjects) != tc.wantProjects {
+				t.Errorf("got %d projects, want %d", len(projects.AllProjects), tc.wantProjects)
+			}
+			if requests != tc.wantRequests {
+				t.Errorf("got %d requests, want %d", requests, tc.wantRequests)
+			}
+		})
+	}
+}
+
+func TestBatchIteratorReportsErrors(t *testing.T) {
+	s := spacetest.NewServer()
+	defer s.Close()
+	for i := 1; i <= 5; i++ {
+		s.AddProject(fmt.Sprintf("P%d", i), fmt.Sprintf("Project %d", i))
+	}
+	// Let the first page through and fail the second.
+	s.Inject(spacetest.Fault{Method: http.MethodGet, Path: "/api/http/projects", Times: 1, Latency: 1})
+	s.Inject(spacetest.Fault{Method: http.MethodGet, Path: "/api/http/projects", Status: http.StatusForbidden, Times: 1})
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.PageSize = 2
+
+	projects, err := c.GetProjects(context.Background(), space.ProjectFilter{})
+	if !space.IsForbidden(err) {
+		t.Fatalf("got error %v, want a 403", err)
+	}
+	if len(projects.AllProjects) != 0 {
+		t.Errorf("got %d projects alongside the error, want none", len(projects.AllProjects))
+	}
+}
+
+// queryValue decodes one parameter of a raw query string.
+func queryValue(rawQuery, key string) string {
+	values, _ := url.ParseQuery(rawQuery)
+	return values.Get(key)
+}
@@ -1,52 +1,178 @@
 package jetbrains_space_api_client_go
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
 	baseAPIEndpoint = "/api/http/projects"
+
+	// defaultRequestTimeout bounds a single attempt of an API call when the
+	// caller's context carries no deadline of its own.
+	defaultRequestTimeout = 30 * time.Second
 )
 
 func NewClient(host, token string) (*Client, error) {
-	c := Client{
-		HTTPClient: &http.Client{Timeout: 10 * time.Second},
-		HostURL:    host,
-		Token:      token,
+	if token == "" {
+		return nil, errors.New("token is undefined")
+	}
+
+	c, err := newClient(host)
+	if err != nil {
+		return nil, err
 	}
+	c.Token = token
+
+	return c, nil
+}
 
+// NormalizeHost turns the forms people write a Space address in, such as
+// "myorg.jetbrains.space" or "https://myorg.jetbrains.space/", into the base
+// URL request paths are appended to.
+func NormalizeHost(host string) (string, error) {
+	host = strings.TrimSpace(host)
 	if host == "" {
-		return nil, errors.New("ERROR: Host is undefined")
+		return "", errors.New("ERROR: Host is undefined")
 	}
-	if token == "" {
-		return nil, errors.New("token is undefined")
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
 	}
 
-	return &c, nil
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("unsupported scheme %q, expected https", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return "", errors.New("missing host name")
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", errors.New("must not contain credentials, a query or a fragment")
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+
+	return u.String(), nil
+}
+
+// newClient returns a client for host with the default timeout, retry and
+// rate limiting settings, but no credentials.
+func newClient(host string) (*Client, error) {
+	host, err := NormalizeHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		HTTPClient:     &http.Client{},
+		HostURL:        host,
+		RequestTimeout: defaultRequestTimeout,
+		MaxRetries:     defaultMaxRetries,
+		RetryWaitMin:   defaultRetryWaitMin,
+		RetryWaitMax:   defaultRetryWaitMax,
+		PageSize:       defaultPageSize,
+	}
+	c.SetRateLimit(defaultRequestsPerSecond, defaultRequestsPerSecond)
+	c.SetMaxConcurrency(defaultMaxConcurrentRequests)
+
+	return c, nil
 }
 
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
 	req.Header.Set("Accept", "application/json")
 
-	res, err := c.HTTPClient.Do(req)
+	retryable := isRetryable(req)
+	refreshed := false
+	sent := 0
+	for attempt := 0; ; attempt++ {
+		token, err := c.bearerToken(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+		body, res, err := c.send(req, sent)
+		sent++
+		if err == nil && res.StatusCode == http.StatusOK {
+			return body, nil
+		}
+
+		// An application token may have been revoked or expired early; get
+		// a new one and repeat the request once, whatever its method.
+		if err == nil && res.StatusCode == http.StatusUnauthorized && c.credentials != nil && !refreshed {
+			refreshed = true
+			c.invalidateToken(token)
+			attempt--
+			continue
+		}
+
+		if !retryable || attempt >= c.MaxRetries || !shouldRetry(res, err) || req.Context().Err() != nil {
+			if err != nil {
+				return nil, err
+			}
+			return nil, newAPIError(req, res.StatusCode, body)
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(c.backoff(attempt, res)):
+		}
+	}
+}
+
+// send performs a single attempt of req. The response body is drained and
+// closed before returning, so only the status and headers of res are usable.
+func (c *Client) send(req *http.Request, attempt int) ([]byte, *http.Response, error) {
+	ctx := req.Context()
+	if _, ok := ctx.Deadline(); !ok && c.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.RequestTimeout)
+		defer cancel()
+	}
+
+	r := req.Clone(ctx)
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		r.Body = body
+	}
+
+	release, err := c.acquire(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	defer res.Body.Close()
+	defer release()
 
-	body, err := io.ReadAll(res.Body)
+	logCtx := c.logContext(r)
+	logRequest(logCtx, r, attempt)
+	start := time.Now()
+
+	res, err := c.HTTPClient.Do(r)
 	if err != nil {
-		return nil, err
+		tflog.Debug(logCtx, "Space API request failed", map[string]interface{}{"error": err.Error()})
+		return nil, nil, err
 	}
+	defer res.Body.Close()
 
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
 	}
+	logResponse(logCtx, r, res, body, time.Since(start))
 
-	return body, err
+	return body, res, nil
 }
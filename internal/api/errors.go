@@ -0,0 +1,95 @@
+package jetbrains_space_api_client_go
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors an *APIError matches through errors.Is, so callers can
+// branch on the kind of failure without inspecting status codes.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// APIError is returned for any non-successful response from Space.
+type APIError struct {
+	StatusCode int
+	// Code and Description are Space's "error" and "error_description"
+	// fields, when the response body carries them.
+	Code        string
+	Description string
+	Method      string
+	Path        string
+	// Body holds the raw response when it isn't a Space error document.
+	Body string
+}
+
+func newAPIError(req *http.Request, statusCode int, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: statusCode,
+		Method:     req.Method,
+		Path:       req.URL.Path,
+	}
+
+	var payload struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && (payload.Error != "" || payload.ErrorDescription != "") {
+		e.Code = payload.Error
+		e.Description = payload.ErrorDescription
+	} else {
+		e.Body = strings.TrimSpace(string(body))
+	}
+
+	return e
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	switch {
+	case e.Code != "" && e.Description != "":
+		msg += fmt.Sprintf(": %s: %s", e.Code, e.Description)
+	case e.Code != "" || e.Description != "":
+		msg += ": " + e.Code + e.Description
+	case e.Body != "":
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+// Is maps the HTTP status onto the package's sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	}
+	return false
+}
+
+// IsNotFound reports whether err, or any error it wraps, is a 404 from Space.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict reports whether err, or any error it wraps, is a 409 from Space.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsForbidden reports whether err, or any error it wraps, is a 403 from Space.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
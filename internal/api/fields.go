@@ -0,0 +1,97 @@
+package jetbrains_space_api_client_go
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Field is one entry of a Space partial-response selection, optionally with
+// nested sub-fields, e.g. memberTeams(name).
+type Field struct {
+	Name   string
+	Fields Fields
+}
+
+// F is shorthand for building a Field.
+func F(name string, fields ...Field) Field {
+	return Field{Name: name, Fields: fields}
+}
+
+// Fields is a $fields selection.
+type Fields []Field
+
+func (f Field) String() string {
+	if len(f.Fields) == 0 {
+		return f.Name
+	}
+	return f.Name + "(" + f.Fields.String() + ")"
+}
+
+// String renders the selection in Space's syntax, unescaped.
+func (f Fields) String() string {
+	parts := make([]string, 0, len(f))
+	for _, field := range f {
+		parts = append(parts, field.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// Query returns the selection as a $fields query parameter.
+func (f Fields) Query() url.Values {
+	return url.Values{"$fields": {f.String()}}
+}
+
+// batchFields wraps an element selection in Space's batch envelope.
+func batchFields(fields Fields) Fields {
+	return Fields{F("next"), F("totalCount"), F("data", fields...)}
+}
+
+// endpoint builds an absolute URL for path with the query escaped.
+func (c *Client) endpoint(path string, query url.Values) string {
+	if len(query) == 0 {
+		return c.HostURL + path
+	}
+	return fmt.Sprintf("%s%s?%s", c.HostURL, path, query.Encode())
+}
+
+var (
+	projectFields = Fields{
+		F("id"),
+		F("archived"),
+		F("createdAt"),
+		F("description"),
+		F("icon"),
+		F("key"),
+		F("latestRepositoryActivity"),
+		F("name"),
+		F("private"),
+		F("memberTeams", F("name")),
+		F("members", F("profile", F("username"))),
+		F("adminTeams", F("name")),
+		F("adminProfiles", F("username")),
+	}
+
+	repositoryFields = Fields{
+		F("id"),
+		F("name"),
+		F("description"),
+		F("state"),
+		F("latestActivity"),
+		F("defaultBranch", F("head"), F("ref")),
+	}
+
+	protectedBranchesFields = Fields{
+		F("protectedBranches",
+			F("allowCreate"),
+			F("allowDelete"),
+			F("allowForcePush"),
+			F("allowPush"),
+			F("pattern"),
+			F("qualityGate",
+				F("approvals", F("approvedBy"), F("minApprovals")),
+				F("automationJobs"),
+			),
+		),
+	}
+)
@@ -0,0 +1,99 @@
+package jetbrains_space_api_client_go
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestFieldsString(t *testing.T) {
+	cases := []struct {
+		name   string
+		fields Fields
+		want   string
+	}{
+		{name: "empty", fields: Fields{}, want: ""},
+		{name: "flat", fields: Fields{F("id"), F("name")}, want: "id,name"},
+		{
+			name:   "nested",
+			fields: Fields{F("id"), F("members", F("profile", F("username")))},
+			want:   "id,members(profile(username))",
+		},
+		{
+			name: "siblings inside nesting",
+			fields: Fields{
+				F("defaultBranch", F("head"), F("ref")),
+				F("memberTeams", F("name")),
+			},
+			want: "defaultBranch(head,ref),memberTeams(name)",
+		},
+		{
+			name:   "batch envelope",
+			fields: batchFields(Fields{F("id"), F("key", F("key"))}),
+			want:   "next,totalCount,data(id,key(key))",
+		},
+		{
+			name:   "nested batch",
+			fields: batchFields(batchFields(Fields{F("id")})),
+			want:   "next,totalCount,data(next,totalCount,data(id))",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.fields.String(); got != tc.want {
+				t.Errorf("String() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFieldsQueryEscaping(t *testing.T) {
+	fields := Fields{F("id"), F("members", F("profile", F("username"))), F("key", F("key"))}
+
+	query := fields.Query()
+	if got, want := query.Encode(), "%24fields=id%2Cmembers%28profile%28username%29%29%2Ckey%28key%29"; got != want {
+		t.Errorf("Query().Encode() = %q, want %q", got, want)
+	}
+
+	// The escaped form must round-trip to the selection Space parses.
+	decoded, err := url.ParseQuery(query.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := decoded.Get("$fields"), "id,members(profile(username)),key(key)"; got != want {
+		t.Errorf("decoded $fields = %q, want %q", got, want)
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	c := &Client{HostURL: "https://myorg.jetbrains.space"}
+
+	cases := []struct {
+		name  string
+		path  string
+		query url.Values
+		want  string
+	}{
+		{name: "no query", path: "/api/http/projects/id:1", want: "https://myorg.jetbrains.space/api/http/projects/id:1"},
+		{
+			name:  "fields",
+			path:  "/api/http/projects",
+			query: batchFields(Fields{F("id")}).Query(),
+			want:  "https://myorg.jetbrains.space/api/http/projects?%24fields=next%2CtotalCount%2Cdata%28id%29",
+		},
+		{
+			name:  "values needing escapes",
+			path:  "/api/http/projects",
+			query: url.Values{"term": {"a&b c"}},
+			want:  "https://myorg.jetbrains.space/api/http/projects?term=a%26b+c",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := c.endpoint(tc.path, tc.query); got != tc.want {
+				t.Errorf("endpoint() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
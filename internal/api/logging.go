@@ -0,0 +1,111 @@
+package jetbrains_space_api_client_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	redacted = "[REDACTED]"
+
+	// maxLoggedBody keeps debug logs readable when Space returns large lists.
+	maxLoggedBody = 4096
+)
+
+// sensitiveHeaders are never written to the log, whatever their value.
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// logContext registers masking for every secret the client knows about, so
+// that neither our own log lines nor anything logged further down can leak
+// them.
+func (c *Client) logContext(req *http.Request) context.Context {
+	ctx := tflog.MaskFieldValuesWithFieldKeys(req.Context(), "authorization", "token", "client_secret", "access_token")
+
+	var secrets []string
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		secrets = append(secrets, strings.TrimPrefix(auth, "Bearer "))
+	}
+	if c.Token != "" {
+		secrets = append(secrets, c.Token)
+	}
+	if c.credentials != nil {
+		secrets = append(secrets, c.credentials.clientSecret)
+	}
+	if len(secrets) > 0 {
+		ctx = tflog.MaskLogStrings(ctx, secrets...)
+	}
+
+	return ctx
+}
+
+func logRequest(ctx context.Context, req *http.Request, attempt int) {
+	fields := map[string]interface{}{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"attempt": attempt + 1,
+		"headers": loggableHeaders(req.Header),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			buf := new(strings.Builder)
+			_, _ = io.Copy(buf, body)
+			_ = body.Close()
+			fields["body"] = loggableBody(req, buf.String())
+		}
+	}
+	tflog.Debug(ctx, "Sending Space API request", fields)
+}
+
+func logResponse(ctx context.Context, req *http.Request, res *http.Response, body []byte, elapsed time.Duration) {
+	tflog.Debug(ctx, "Received Space API response", map[string]interface{}{
+		"method":      req.Method,
+		"url":         req.URL.String(),
+		"status":      res.StatusCode,
+		"duration_ms": elapsed.Milliseconds(),
+		"headers":     loggableHeaders(res.Header),
+		"body":        loggableBody(req, string(body)),
+	})
+}
+
+func loggableHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if sensitiveHeaders[http.CanonicalHeaderKey(k)] {
+			out[k] = redacted
+			continue
+		}
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+// loggableBody hides bodies that carry credentials, replaces anything that
+// isn't JSON, such as uploaded icons, with its size and truncates the rest.
+// The token endpoint is matched as a suffix because the host may carry a path
+// prefix.
+func loggableBody(req *http.Request, body string) string {
+	if strings.HasSuffix(req.URL.Path, oauthTokenEndpoint) {
+		return redacted
+	}
+	if body == "" {
+		return body
+	}
+	if !json.Valid([]byte(body)) {
+		return fmt.Sprintf("[%d bytes, not JSON]", len(body))
+	}
+	if len(body) > maxLoggedBody {
+		return body[:maxLoggedBody] + "...(truncated)"
+	}
+	return body
+}
@@ -0,0 +1,82 @@
+package jetbrains_space_api_client_go
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLoggableBody(t *testing.T) {
+	longJSON := `["` + strings.Repeat("a", maxLoggedBody) + `"]`
+
+	cases := []struct {
+		name string
+		url  string
+		body string
+		want string
+	}{
+		{
+			name: "token endpoint",
+			url:  "https://myorg.jetbrains.space/oauth/token",
+			body: `{"access_token":"secret"}`,
+			want: redacted,
+		},
+		{
+			name: "token endpoint below a path prefix",
+			url:  "https://corp.example.com/space/oauth/token",
+			body: `{"access_token":"secret"}`,
+			want: redacted,
+		},
+		{
+			name: "empty",
+			url:  "https://myorg.jetbrains.space/api/http/projects/id:1",
+			body: "",
+			want: "",
+		},
+		{
+			name: "json",
+			url:  "https://myorg.jetbrains.space/api/http/projects/id:1",
+			body: `{"id":"1"}`,
+			want: `{"id":"1"}`,
+		},
+		{
+			name: "long json",
+			url:  "https://myorg.jetbrains.space/api/http/projects",
+			body: longJSON,
+			want: longJSON[:maxLoggedBody] + "...(truncated)",
+		},
+		{
+			name: "binary upload",
+			url:  "https://myorg.jetbrains.space/uploads/abc/icon.png",
+			body: "\x89PNG\r\n\x1a\n\x00\x00",
+			want: "[10 bytes, not JSON]",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, tc.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := loggableBody(req, tc.body); got != tc.want {
+				t.Errorf("loggableBody() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoggableHeaders(t *testing.T) {
+	got := loggableHeaders(http.Header{
+		"Authorization": {"Bearer secret"},
+		"Set-Cookie":    {"session=secret"},
+		"Content-Type":  {"application/json"},
+	})
+
+	if got["Authorization"] != redacted || got["Set-Cookie"] != redacted {
+		t.Errorf("credentials not redacted: %v", got)
+	}
+	if got["Content-Type"] != "application/json" {
+		t.Errorf("Content-Type = %q, want it logged as is", got["Content-Type"])
+	}
+}
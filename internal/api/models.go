@@ -1,30 +1,47 @@
 package jetbrains_space_api_client_go
 
-import "net/http"
+import (
+	"net/http"
+	"sync"
+	"time"
+)
 
 type Client struct {
 	HostURL    string
 	HTTPClient *http.Client
 	Token      string
+	// RequestTimeout is applied to each attempt of a call whose context has
+	// no deadline.
+	RequestTimeout time.Duration
+	// MaxRetries is how many times a retryable request is repeated after a
+	// throttled or transient failure. Zero disables retries.
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// PageSize is the $top sent with every list request.
+	PageSize int
+
+	limiter     *rateLimiter
+	inFlight    chan struct{}
+	credentials *clientCredentials
+
+	// self caches CurrentUsername.
+	selfMu sync.Mutex
+	self   *string
 }
 
 type Project struct {
-	ID  string `json:"id"`
-	Key struct {
-		Key string `json:"key"`
-	} `json:"key"`
-	Name                     string      `json:"name"`
-	Private                  bool        `json:"private"`
-	Description              string      `json:"description"`
-	Icon                     interface{} `json:"icon"`
-	LatestRepositoryActivity interface{} `json:"latestRepositoryActivity"`
-	CreatedAt                struct {
-		Iso       string `json:"iso"`
-		Timestamp int64  `json:"timestamp"`
-	} `json:"createdAt"`
-	Archived    bool           `json:"archived"`
-	MemberTeams []ProjectTeams `json:"memberTeams"`
-	Members     []struct {
+	ID                       string         `json:"id"`
+	Key                      ProjectKey     `json:"key"`
+	Name                     string         `json:"name"`
+	Private                  bool           `json:"private"`
+	Description              string         `json:"description"`
+	Icon                     string         `json:"icon"`
+	LatestRepositoryActivity *DateTime      `json:"latestRepositoryActivity"`
+	CreatedAt                DateTime       `json:"createdAt"`
+	Archived                 bool           `json:"archived"`
+	MemberTeams              []ProjectTeams `json:"memberTeams"`
+	Members                  []struct {
 		Profile struct {
 			Username string `json:"username"`
 		} `json:"profile"`
@@ -35,39 +52,72 @@ type Project struct {
 	} `json:"adminProfiles"`
 }
 
+// DateTime is how Space encodes points in time.
+type DateTime struct {
+	Iso       string `json:"iso"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Time returns t in UTC.
+func (t DateTime) Time() time.Time {
+	return time.UnixMilli(t.Timestamp).UTC()
+}
+
+type ProjectKey struct {
+	Key string `json:"key"`
+}
+
 type ProjectTeams struct {
 	Name string `json:"name"`
 }
 
 type ProjectRepos struct {
-	Repos []struct {
-		ID                        string      `json:"id"`
-		Name                      string      `json:"name"`
-		Description               string      `json:"description"`
-		LatestActivity            interface{} `json:"latestActivity"`
-		ProxyPushNotification     interface{} `json:"proxyPushNotification"`
-		ProxyPushNotificationBody interface{} `json:"proxyPushNotificationBody"`
-		State                     string      `json:"state"`
-		InitProgress              interface{} `json:"initProgress"`
-		ReadmeName                interface{} `json:"readmeName"`
-		MonthlyActivity           interface{} `json:"monthlyActivity"`
-		DefaultBranch             struct {
-			Head string `json:"head"`
-			Ref  string `json:"ref"`
-		} `json:"defaultBranch"`
-	} `json:"repos"`
+	Repos []Repository `json:"repos"`
 }
 
 type ProjectRoles struct {
-	Team        string        `json:"team"`
-	AddRoles    []interface{} `json:"addRoles"`
-	RemoveRoles []interface{} `json:"removeRoles"`
+	Team        string   `json:"team"`
+	AddRoles    []string `json:"addRoles"`
+	RemoveRoles []string `json:"removeRoles"`
 }
 
 type ProjectMembers struct {
-	Profile     string        `json:"profile"`
-	AddRoles    []interface{} `json:"addRoles"`
-	RemoveRoles []interface{} `json:"removeRoles"`
+	Profile     string   `json:"profile"`
+	AddRoles    []string `json:"addRoles"`
+	RemoveRoles []string `json:"removeRoles"`
+}
+
+// ProjectRole is a role defined in a project. Built-in roles have the keys
+// "admin" and "member"; custom roles get a key when they are created.
+type ProjectRole struct {
+	ID          string   `json:"id"`
+	Key         string   `json:"key"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+type CreateProjectRoleData struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// ProjectPeople lists every profile and team with a role in a project,
+// including custom roles that Project does not report.
+type ProjectPeople struct {
+	Profiles []struct {
+		Profile struct {
+			Username string `json:"username"`
+		} `json:"profile"`
+		Roles []ProjectRoleKey `json:"roles"`
+	} `json:"profiles"`
+	Teams []struct {
+		Team  ProjectTeams     `json:"team"`
+		Roles []ProjectRoleKey `json:"roles"`
+	} `json:"teams"`
+}
+
+type ProjectRoleKey struct {
+	Key string `json:"key"`
 }
 
 type Repository struct {
@@ -87,6 +137,12 @@ type Repository struct {
 	} `json:"defaultBranch"`
 }
 
+// RepositoryURL holds the clone URLs of a repository.
+type RepositoryURL struct {
+	HTTPURL string `json:"httpUrl"`
+	SSHURL  string `json:"sshUrl"`
+}
+
 type CreateRepositoryData struct {
 	Description   string `json:"description"`
 	DefaultBranch string `json:"defaultBranch"`
@@ -94,6 +150,22 @@ type CreateRepositoryData struct {
 	DefaultSetup  bool   `json:"defaultSetup"`
 }
 
+type CreateProjectData struct {
+	Key         ProjectKey `json:"key"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Private     bool       `json:"private"`
+}
+
+// ProjectFilter narrows GetProjects on the Space side. The zero value matches
+// every project.
+type ProjectFilter struct {
+	// Term is searched for in project names and keys.
+	Term string
+	// Starred keeps only the projects starred by the authenticated user.
+	Starred bool
+}
+
 type Projects struct {
 	AllProjects []Project `json:"data"`
 }
@@ -103,11 +175,3 @@ type AutomationJobs struct {
 	Name       string `json:"name"`
 	Repository string `json:"repoName"`
 }
-
-type AllAutomationJobs struct {
-	Data []struct {
-		Id         string `json:"id"`
-		Name       string `json:"name"`
-		Repository string `json:"repoName"`
-	} `json:"data"`
-}
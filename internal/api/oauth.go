@@ -0,0 +1,137 @@
+package jetbrains_space_api_client_go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	oauthTokenEndpoint = "/oauth/token"
+
+	// defaultScope grants a Space application every permission it has been
+	// given in the organization.
+	defaultScope = "**"
+
+	// tokenExpiryMargin refreshes access tokens slightly early so a request
+	// never goes out with a token that expires in transit.
+	tokenExpiryMargin = 30 * time.Second
+)
+
+// clientCredentials performs the OAuth 2.0 client-credentials flow for a
+// Space application and caches the resulting access token.
+type clientCredentials struct {
+	clientID     string
+	clientSecret string
+	scope        string
+
+	mu          sync.Mutex
+	accessToken string
+	expiry      time.Time
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// NewClientWithCredentials returns a client that authenticates as a Space
+// application, exchanging its client ID and secret for access tokens as
+// needed. An empty scope requests all of the application's permissions.
+func NewClientWithCredentials(host, clientID, clientSecret, scope string) (*Client, error) {
+	if clientID == "" || clientSecret == "" {
+		return nil, errors.New("client ID and client secret are required")
+	}
+	if scope == "" {
+		scope = defaultScope
+	}
+
+	c, err := newClient(host)
+	if err != nil {
+		return nil, err
+	}
+	c.credentials = &clientCredentials{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scope:        scope,
+	}
+
+	return c, nil
+}
+
+// bearerToken returns the token to authenticate the next request with,
+// fetching a fresh access token when the cached one is missing or expiring.
+func (c *Client) bearerToken(ctx context.Context) (string, error) {
+	if c.credentials == nil {
+		return c.Token, nil
+	}
+
+	cc := c.credentials
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if cc.accessToken != "" && time.Now().Add(tokenExpiryMargin).Before(cc.expiry) {
+		return cc.accessToken, nil
+	}
+
+	token, err := c.requestAccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	cc.accessToken = token.AccessToken
+	cc.expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return cc.accessToken, nil
+}
+
+// invalidateToken drops the cached access token after Space rejected it, so
+// the next bearerToken call performs a fresh exchange.
+func (c *Client) invalidateToken(rejected string) {
+	if c.credentials == nil {
+		return
+	}
+	c.credentials.mu.Lock()
+	defer c.credentials.mu.Unlock()
+	if c.credentials.accessToken == rejected {
+		c.credentials.accessToken = ""
+	}
+}
+
+func (c *Client) requestAccessToken(ctx context.Context) (tokenResponse, error) {
+	cc := c.credentials
+	form := url.Values{
+		"grant_type": {"client_credentials"},
+		"scope":      {cc.scope},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint(oauthTokenEndpoint, nil), bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return tokenResponse{}, err
+	}
+	req.SetBasicAuth(cc.clientID, cc.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	body, res, err := c.send(req, 0)
+	if err != nil {
+		return tokenResponse{}, fmt.Errorf("problem requesting access token: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return tokenResponse{}, fmt.Errorf("problem requesting access token: %w", newAPIError(req, res.StatusCode, body))
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return tokenResponse{}, fmt.Errorf("problem decoding access token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return tokenResponse{}, errors.New("problem requesting access token: response carried no access_token")
+	}
+
+	return token, nil
+}
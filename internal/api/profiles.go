@@ -0,0 +1,45 @@
+package jetbrains_space_api_client_go
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+const currentProfileEndpoint = "/api/http/team-directory/profiles/me"
+
+// CurrentUsername returns the username of the profile the client
+// authenticates as. Applications using client credentials have no profile,
+// so for them it returns "". The answer is cached for the client's lifetime.
+func (c *Client) CurrentUsername(ctx context.Context) (string, error) {
+	c.selfMu.Lock()
+	defer c.selfMu.Unlock()
+	if c.self != nil {
+		return *c.self, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint(currentProfileEndpoint, Fields{F("username")}.Query()), nil)
+	if err != nil {
+		return "", err
+	}
+
+	username := ""
+	body, err := c.doRequest(req)
+	switch {
+	case IsForbidden(err) || IsNotFound(err):
+		// Not a profile, such as an application.
+	case err != nil:
+		return "", err
+	default:
+		var profile struct {
+			Username string `json:"username"`
+		}
+		if err := json.Unmarshal(body, &profile); err != nil {
+			return "", err
+		}
+		username = profile.Username
+	}
+
+	c.self = &username
+	return username, nil
+}
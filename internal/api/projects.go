@@ -2,33 +2,43 @@ package jetbrains_space_api_client_go
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetProjects() (Projects, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/http/projects", c.HostURL), nil)
-	if err != nil {
-		return Projects{}, err
+// GetProjects lists the projects matching filter, following pagination.
+func (c *Client) GetProjects(ctx context.Context, filter ProjectFilter) (Projects, error) {
+	query := batchFields(projectFields).Query()
+	if filter.Term != "" {
+		query.Set("term", filter.Term)
 	}
-	body, err := c.doRequest(req)
-	if err != nil {
-		return Projects{}, err
+	if filter.Starred {
+		query.Set("starred", "true")
 	}
-	projects := Projects{}
-	err = json.Unmarshal(body, &projects)
+	projects, err := listAll[Project](ctx, c, baseAPIEndpoint, query)
 	if err != nil {
 		return Projects{}, err
 	}
 
-	return projects, nil
+	return Projects{AllProjects: projects}, nil
+
+}
+
+func (c *Client) GetProject(ctx context.Context, id string) (Project, error) {
+	return c.getProject(ctx, "id:"+id)
+}
 
+// GetProjectByKey looks a project up by its key rather than its ID.
+func (c *Client) GetProjectByKey(ctx context.Context, key string) (Project, error) {
+	return c.getProject(ctx, "key:"+key)
 }
 
-func (c *Client) GetProject(id string) (Project, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/http/projects/id:%s?$fields=id,archived,createdAt,description,icon,key,latestRepositoryActivity,name,private,memberTeams(name),members(profile(username)),adminTeams(name),adminProfiles(username)", c.HostURL, id), nil)
+// getProject fetches a project by a Space identifier such as id:X or key:X.
+func (c *Client) getProject(ctx context.Context, identifier string) (Project, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint(fmt.Sprintf("%s/%s", baseAPIEndpoint, identifier), projectFields.Query()), nil)
 	if err != nil {
 		return Project{}, err
 	}
@@ -47,8 +57,8 @@ func (c *Client) GetProject(id string) (Project, error) {
 	return project, nil
 }
 
-func (c *Client) getProjectRepos(projectId string) (ProjectRepos, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s/id:%s?$fields=repos", c.HostURL, baseAPIEndpoint, projectId), nil)
+func (c *Client) getProjectRepos(ctx context.Context, projectId string) (ProjectRepos, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint(fmt.Sprintf("%s/id:%s", baseAPIEndpoint, projectId), Fields{F("repos", repositoryFields...)}.Query()), nil)
 	if err != nil {
 		return ProjectRepos{}, err
 	}
@@ -67,17 +77,16 @@ func (c *Client) getProjectRepos(projectId string) (ProjectRepos, error) {
 	return project, nil
 }
 
-func (c *Client) CreateProject(name string) (Project, error) {
-	data := new(struct {
-		Key struct {
-			Key string `json:"key"`
-		} `json:"key"`
-		Name string `json:"name"`
-	})
-	data.Name = name
-	data.Key.Key = strings.ToUpper(strings.ReplaceAll(name, " ", "-"))
+// CreateProject creates a project. An empty key is derived from the name.
+func (c *Client) CreateProject(ctx context.Context, data CreateProjectData) (Project, error) {
+	if data.Key.Key == "" {
+		data.Key.Key = DefaultProjectKey(data.Name)
+		if data.Key.Key == "" {
+			return Project{}, fmt.Errorf("cannot derive a project key from name %q", data.Name)
+		}
+	}
 	bytesData, _ := json.Marshal(data)
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s", c.HostURL, baseAPIEndpoint), bytes.NewBuffer(bytesData))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s", c.HostURL, baseAPIEndpoint), bytes.NewBuffer(bytesData))
 	if err != nil {
 		return Project{}, err
 	}
@@ -96,13 +105,25 @@ func (c *Client) CreateProject(name string) (Project, error) {
 	return project, nil
 }
 
-func (c *Client) UpdateProject(id string, project Project) (Project, error) {
-	data := new(struct {
-		Name string `json:"name"`
-	})
-	data.Name = project.Name
+// UpdateProject sets a project's name, description and privacy and, when
+// project.Key.Key is set, changes its key. The icon is left alone; see
+// SetProjectIcon.
+func (c *Client) UpdateProject(ctx context.Context, id string, project Project) (Project, error) {
+	data := struct {
+		Name        string      `json:"name"`
+		Key         *ProjectKey `json:"key,omitempty"`
+		Description string      `json:"description"`
+		Private     bool        `json:"private"`
+	}{
+		Name:        project.Name,
+		Description: project.Description,
+		Private:     project.Private,
+	}
+	if project.Key.Key != "" {
+		data.Key = &project.Key
+	}
 	bytesData, _ := json.Marshal(data)
-	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s%s/id:%s", c.HostURL, baseAPIEndpoint, id), bytes.NewBuffer(bytesData))
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s%s/id:%s", c.HostURL, baseAPIEndpoint, id), bytes.NewBuffer(bytesData))
 	if err != nil {
 		return Project{}, err
 	}
@@ -121,8 +142,50 @@ func (c *Client) UpdateProject(id string, project Project) (Project, error) {
 	return updatedProject, nil
 }
 
-func (c *Client) DeleteProject(id string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s%s/id:%s", c.HostURL, baseAPIEndpoint, id), nil)
+// SetProjectIcon replaces a project's icon with a previously uploaded
+// attachment. An empty iconID removes the icon.
+func (c *Client) SetProjectIcon(ctx context.Context, id, iconID string) error {
+	data := map[string]interface{}{"icon": nil}
+	if iconID != "" {
+		data["icon"] = iconID
+	}
+	bytesData, _ := json.Marshal(data)
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s%s/id:%s", c.HostURL, baseAPIEndpoint, id), bytes.NewBuffer(bytesData))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return fmt.Errorf("problem setting project icon: %w", err)
+	}
+
+	return nil
+}
+
+// DefaultProjectKey derives a key from a project name the way the Space UI
+// suggests one: upper-cased, with every run of other characters collapsed
+// into a single hyphen. It returns "" for a name with no ASCII letters or
+// digits.
+func DefaultProjectKey(name string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToUpper(name) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingHyphen = true
+	}
+	return b.String()
+}
+
+func (c *Client) DeleteProject(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s%s/id:%s", c.HostURL, baseAPIEndpoint, id), nil)
 	if err != nil {
 		return err
 	}
@@ -135,22 +198,47 @@ func (c *Client) DeleteProject(id string) error {
 	return nil
 }
 
-func (c *Client) MapTeamToProjectRole(data ProjectRoles, projectID string) error {
+// ArchiveProject archives a project, keeping its data but making it
+// read-only.
+func (c *Client) ArchiveProject(ctx context.Context, id string) error {
+	return c.postProjectAction(ctx, id, "archive")
+}
+
+// UnarchiveProject restores an archived project.
+func (c *Client) UnarchiveProject(ctx context.Context, id string) error {
+	return c.postProjectAction(ctx, id, "unarchive")
+}
+
+func (c *Client) postProjectAction(ctx context.Context, id, action string) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s/id:%s/%s", c.HostURL, baseAPIEndpoint, id, action), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return fmt.Errorf("problem calling %s on project %s: %w", action, id, err)
+	}
+
+	return nil
+}
+
+func (c *Client) MapTeamToProjectRole(ctx context.Context, data ProjectRoles, projectID string) error {
 
+	data.AddRoles = nonNilRoles(data.AddRoles)
+	data.RemoveRoles = nonNilRoles(data.RemoveRoles)
 	jsonData, err := json.Marshal(data)
-	jsonData = bytes.Replace(jsonData, []byte("\"\""), []byte(""), 1)
 	if err != nil {
 		return fmt.Errorf("Problem converting request data to valid json")
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/id:%s/people/teams/update", c.HostURL, baseAPIEndpoint, projectID), bytes.NewBuffer(jsonData))
-	req.Header.Add("Accept", "Application/json")
-	req.Header.Add("Content-Type", "Application/Json")
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s/id:%s/people/teams/update", c.HostURL, baseAPIEndpoint, projectID), bytes.NewBuffer(jsonData))
 	if err != nil {
-		return fmt.Errorf("Problem initiating request to update project roles via API! " + err.Error())
+		return fmt.Errorf("problem initiating request to update project roles: %w", err)
 	}
+	req.Header.Add("Content-Type", "application/json")
 	_, err = c.doRequest(req)
 	if err != nil {
-		return fmt.Errorf("Problem getting response from project roles (Updating) " + string(jsonData) + err.Error())
+		return fmt.Errorf("problem updating project roles with %s: %w", jsonData, err)
 
 	}
 
@@ -158,41 +246,42 @@ func (c *Client) MapTeamToProjectRole(data ProjectRoles, projectID string) error
 
 }
 
-func (c *Client) GetTeamToProjectRole(projectID string) ([]ProjectTeams, error) {
-	projectSettings, err := c.GetProject(projectID)
+func (c *Client) GetTeamToProjectRole(ctx context.Context, projectID string) ([]ProjectTeams, error) {
+	projectSettings, err := c.GetProject(ctx, projectID)
 	if err != nil {
-		return []ProjectTeams{}, fmt.Errorf("Problem getting project settings" + projectID + " " + err.Error())
+		return []ProjectTeams{}, fmt.Errorf("problem getting project settings for %s: %w", projectID, err)
 	}
 
 	return projectSettings.MemberTeams, nil
 
 }
 
-func (c *Client) GetProjectMembers(projectID string) (Project, error) {
-	projectSettings, err := c.GetProject(projectID)
+func (c *Client) GetProjectMembers(ctx context.Context, projectID string) (Project, error) {
+	projectSettings, err := c.GetProject(ctx, projectID)
 	if err != nil {
-		return Project{}, fmt.Errorf("Problem getting project settings" + projectID + " " + err.Error())
+		return Project{}, fmt.Errorf("problem getting project settings for %s: %w", projectID, err)
 	}
 
 	return projectSettings, nil
 
 }
 
-func (c *Client) SetProjectMembers(data ProjectMembers, projectID string) error {
+func (c *Client) SetProjectMembers(ctx context.Context, data ProjectMembers, projectID string) error {
 
-	jsonData, err := toJson(data)
+	data.AddRoles = nonNilRoles(data.AddRoles)
+	data.RemoveRoles = nonNilRoles(data.RemoveRoles)
+	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("Error converting jsonData")
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/id:%s/people/members/update", c.HostURL, baseAPIEndpoint, projectID), bytes.NewBuffer(jsonData))
-	req.Header.Add("Accept", "Application/json")
-	req.Header.Add("Content-Type", "Application/Json")
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s/id:%s/people/members/update", c.HostURL, baseAPIEndpoint, projectID), bytes.NewBuffer(jsonData))
 	if err != nil {
-		return fmt.Errorf("Problem initiating request to update project roles via API! " + err.Error())
+		return fmt.Errorf("problem initiating request to update project roles: %w", err)
 	}
+	req.Header.Add("Content-Type", "application/json")
 	_, err = c.doRequest(req)
 	if err != nil {
-		return fmt.Errorf("Problem getting response from project roles (Updating) " + string(jsonData) + err.Error())
+		return fmt.Errorf("problem updating project roles with %s: %w", jsonData, err)
 
 	}
 
@@ -200,14 +289,11 @@ func (c *Client) SetProjectMembers(data ProjectMembers, projectID string) error
 
 }
 
-func toJson(source interface{}) ([]byte, error) {
-
-	jsonData, err := json.Marshal(source)
-	jsonData = bytes.Replace(jsonData, []byte("\"\""), []byte(""), 1)
-	if err != nil {
-		return nil, fmt.Errorf("Problem converting request data to valid json")
+// nonNilRoles makes an empty role list encode as [] rather than null, which
+// Space rejects.
+func nonNilRoles(roles []string) []string {
+	if roles == nil {
+		return []string{}
 	}
-
-	return jsonData, nil
-
+	return roles
 }
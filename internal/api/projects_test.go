@@ -0,0 +1,256 @@
+package jetbrains_space_api_client_go_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	space "terraform-provider-jetbrains-space/internal/api"
+)
+
+func TestProjectLifecycle(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := context.Background()
+
+	created, err := c.CreateProject(ctx, space.CreateProjectData{
+		Key:         space.ProjectKey{Key: "APP"},
+		Name:        "App",
+		Description: "First",
+		Private:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byKey, err := c.GetProjectByKey(ctx, "APP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byKey.ID != created.ID || byKey.Description != "First" || !byKey.Private || byKey.CreatedAt.Timestamp == 0 {
+		t.Errorf("GetProjectByKey = %+v, want the created project", byKey)
+	}
+
+	_, err = c.UpdateProject(ctx, created.ID, space.Project{Name: "Renamed", Key: space.ProjectKey{Key: "RENAMED"}, Description: "Second"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, err := c.GetProject(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Name != "Renamed" || updated.Key.Key != "RENAMED" || updated.Description != "Second" || updated.Private {
+		t.Errorf("GetProject after update = %+v", updated)
+	}
+
+	if err := c.ArchiveProject(ctx, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Project(created.ID).Archived {
+		t.Error("project not archived")
+	}
+	if err := c.UnarchiveProject(ctx, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if s.Project(created.ID).Archived {
+		t.Error("project still archived")
+	}
+
+	if err := c.DeleteProject(ctx, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetProject(ctx, created.ID); !space.IsNotFound(err) {
+		t.Errorf("GetProject after delete: got %v, want a 404", err)
+	}
+}
+
+func TestCreateProjectConflict(t *testing.T) {
+	s, c := newTestClient(t)
+	s.AddProject("APP", "App")
+
+	_, err := c.CreateProject(context.Background(), space.CreateProjectData{Key: space.ProjectKey{Key: "APP"}, Name: "Other"})
+	if !space.IsConflict(err) {
+		t.Errorf("got %v, want a 409", err)
+	}
+}
+
+func TestGetProjectsFilter(t *testing.T) {
+	s, c := newTestClient(t)
+	s.AddProject("APP", "Application")
+	s.AddProject("WEB", "Website")
+	s.AddProject("INFRA", "Infrastructure").Starred = true
+
+	cases := []struct {
+		filter space.ProjectFilter
+		want   []string
+	}{
+		{filter: space.ProjectFilter{}, want: []string{"APP", "WEB", "INFRA"}},
+		{filter: space.ProjectFilter{Term: "web"}, want: []string{"WEB"}},
+		{filter: space.ProjectFilter{Starred: true}, want: []string{"INFRA"}},
+	}
+
+	for _, tc := range cases {
+		projects, err := c.GetProjects(context.Background(), tc.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var keys []string
+		for _, p := range projects.AllProjects {
+			keys = append(keys, p.Key.Key)
+		}
+		if !reflect.DeepEqual(keys, tc.want) {
+			t.Errorf("GetProjects(%+v) = %v, want %v", tc.filter, keys, tc.want)
+		}
+	}
+}
+
+func TestProjectMembership(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := context.Background()
+	p := s.AddProject("APP", "App")
+
+	err := c.SetProjectMembers(ctx, space.ProjectMembers{Profile: "username:alice", AddRoles: []string{"admin", "member"}}, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.MapTeamToProjectRole(ctx, space.ProjectRoles{Team: "name:Backend", AddRoles: []string{"member"}}, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := c.GetProject(ctx, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(project.Admins) != 1 || project.Admins[0].Username != "alice" {
+		t.Errorf("admins = %+v, want alice", project.Admins)
+	}
+	if len(project.MemberTeams) != 1 || project.MemberTeams[0].Name != "Backend" {
+		t.Errorf("member teams = %+v, want Backend", project.MemberTeams)
+	}
+
+	err = c.SetProjectMembers(ctx, space.ProjectMembers{Profile: "username:alice", RemoveRoles: []string{"admin"}}, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	people, err := c.GetProjectPeople(ctx, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := people.ProfileRoles("alice"); !reflect.DeepEqual(got, []string{"member"}) {
+		t.Errorf("roles of alice = %v, want [member]", got)
+	}
+	if got := people.TeamRoles("Backend"); !reflect.DeepEqual(got, []string{"member"}) {
+		t.Errorf("roles of Backend = %v, want [member]", got)
+	}
+
+	// Space rejects role keys the project doesn't define.
+	err = c.SetProjectMembers(ctx, space.ProjectMembers{Profile: "username:bob", AddRoles: []string{"reviewer"}}, p.ID)
+	if err == nil {
+		t.Error("granting an unknown role succeeded")
+	}
+}
+
+func TestProjectRoles(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := context.Background()
+	p := s.AddProject("APP", "App")
+
+	role, err := c.CreateProjectRole(ctx, p.ID, space.CreateProjectRoleData{Name: "Reviewer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role.Key == "" || role.Permissions == nil {
+		t.Errorf("CreateProjectRole = %+v, want a key and no permissions", role)
+	}
+
+	role, err = c.UpdateProjectRole(ctx, p.ID, role.ID, space.CreateProjectRoleData{Name: "Reviewer", Permissions: []string{"VcsRead"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(role.Permissions, []string{"VcsRead"}) {
+		t.Errorf("permissions = %v, want [VcsRead]", role.Permissions)
+	}
+
+	err = c.SetProjectMembers(ctx, space.ProjectMembers{Profile: "username:bob", AddRoles: []string{role.Key}}, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	people, err := c.GetProjectPeople(ctx, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profiles, _ := people.WithRole(role.Key); !reflect.DeepEqual(profiles, []string{"bob"}) {
+		t.Errorf("holders of %s = %v, want [bob]", role.Key, profiles)
+	}
+
+	if err := c.DeleteProjectRole(ctx, p.ID, role.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetProjectRole(ctx, p.ID, role.ID); !space.IsNotFound(err) {
+		t.Errorf("GetProjectRole after delete: got %v, want a 404", err)
+	}
+}
+
+func TestUploadProjectIcon(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := context.Background()
+	p := s.AddProject("APP", "App")
+	icon := []byte("\x89PNG\r\n\x1a\n")
+
+	id, err := c.UploadFile(ctx, "icon.png", "image/png", icon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s.Attachment(id), icon) {
+		t.Errorf("uploaded content = %q, want %q", s.Attachment(id), icon)
+	}
+
+	if err := c.SetProjectIcon(ctx, p.ID, id); err != nil {
+		t.Fatal(err)
+	}
+	if s.Project(p.ID).Icon != id {
+		t.Errorf("icon = %q, want %q", s.Project(p.ID).Icon, id)
+	}
+	if err := c.SetProjectIcon(ctx, p.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if s.Project(p.ID).Icon != "" {
+		t.Errorf("icon = %q, want it removed", s.Project(p.ID).Icon)
+	}
+}
+
+func TestDefaultProjectKey(t *testing.T) {
+	cases := []struct {
+		name string
+		want string
+	}{
+		{name: "App", want: "APP"},
+		{name: "Web App", want: "WEB-APP"},
+		{name: "  web -- app  ", want: "WEB-APP"},
+		{name: "api_v2", want: "API-V2"},
+		{name: "2024 Roadmap!", want: "2024-ROADMAP"},
+		{name: "Café Münster", want: "CAF-M-NSTER"},
+		{name: "", want: ""},
+		{name: "---", want: ""},
+		{name: "Ünïcödé", want: "N-C-D"},
+		{name: "日本語", want: ""},
+	}
+
+	for _, tc := range cases {
+		if got := space.DefaultProjectKey(tc.name); got != tc.want {
+			t.Errorf("DefaultProjectKey(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestCreateProjectWithoutDerivableKey(t *testing.T) {
+	s, c := newTestClient(t)
+
+	_, err := c.CreateProject(context.Background(), space.CreateProjectData{Name: "日本語"})
+	if err == nil {
+		t.Fatal("creating a project without a derivable key succeeded")
+	}
+	if n := countRequests(s, "POST", "/api/http/projects"); n != 0 {
+		t.Errorf("sent %d create requests, want none", n)
+	}
+}
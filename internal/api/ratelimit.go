@@ -0,0 +1,110 @@
+package jetbrains_space_api_client_go
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRequestsPerSecond     = 10
+	defaultMaxConcurrentRequests = 8
+)
+
+// rateLimiter is a token bucket shared by every request made through a
+// Client, regardless of which resource issued it.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	// Reserve a token up front, letting the bucket go negative, so that
+	// concurrent waiters queue behind each other instead of racing.
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// SetRateLimit caps the client at rate requests per second, allowing bursts
+// of up to burst requests. A rate of zero or less removes the limit.
+func (c *Client) SetRateLimit(rate float64, burst int) {
+	if rate <= 0 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = newRateLimiter(rate, burst)
+}
+
+// SetMaxConcurrency caps the number of requests in flight at once. A value of
+// zero or less removes the cap.
+func (c *Client) SetMaxConcurrency(n int) {
+	if n <= 0 {
+		c.inFlight = nil
+		return
+	}
+	c.inFlight = make(chan struct{}, n)
+}
+
+// acquire waits for both a concurrency slot and a rate limit token. The
+// returned func releases the slot and must be called once the response has
+// been consumed.
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+	if c.inFlight != nil {
+		select {
+		case c.inFlight <- struct{}{}:
+			release = func() { <-c.inFlight }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
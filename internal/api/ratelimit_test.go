@@ -0,0 +1,163 @@
+package jetbrains_space_api_client_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := newRateLimiter(10, 3)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("the burst of 3 took %v, want no wait", elapsed)
+	}
+
+	// The bucket is empty, so the next request waits for one token: 100ms
+	// at 10 per second.
+	start = time.Now()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond || elapsed > 250*time.Millisecond {
+		t.Errorf("the request after the burst waited %v, want about 100ms", elapsed)
+	}
+}
+
+func TestRateLimiterSpacing(t *testing.T) {
+	l := newRateLimiter(50, 1)
+	ctx := context.Background()
+
+	// The first request uses the burst; the other 5 are 20ms apart.
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond || elapsed > 400*time.Millisecond {
+		t.Errorf("6 requests at 50 per second took %v, want about 100ms", elapsed)
+	}
+}
+
+func TestRateLimiterSpacingConcurrent(t *testing.T) {
+	l := newRateLimiter(50, 1)
+	ctx := context.Background()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Wait(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("6 concurrent requests at 50 per second took %v, want at least 100ms", elapsed)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	l := newRateLimiter(1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := l.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("cancelled wait returned after %v, want as soon as the context is done", elapsed)
+	}
+
+	// The cancelled request gives its reservation back, so it doesn't delay
+	// the next one by another second.
+	l.mu.Lock()
+	tokens := l.tokens
+	l.mu.Unlock()
+	if tokens < -0.5 {
+		t.Errorf("tokens = %v after cancelling, want the reservation returned", tokens)
+	}
+}
+
+func TestAcquireCancelWhileFull(t *testing.T) {
+	c := &Client{}
+	c.SetMaxConcurrency(1)
+	release, err := c.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestMaxConcurrency(t *testing.T) {
+	const limit = 3
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetRateLimit(0, 0)
+	c.SetMaxConcurrency(limit)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL+"/api/http/projects", nil)
+			if _, err := c.doRequest(req); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > limit {
+		t.Errorf("%d requests were in flight at once, want at most %d", peak, limit)
+	}
+	if peak < limit {
+		t.Errorf("at most %d requests were in flight at once, want the cap of %d reached", peak, limit)
+	}
+}
@@ -2,9 +2,11 @@ package jetbrains_space_api_client_go
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type ProtectedBranches struct {
@@ -43,22 +45,62 @@ type ProtectedBranchesResultApprovals struct {
 	MinApprovals int      `json:"minApprovals"`
 }
 
-func (c *Client) GetRepository(repositoryName, projectId string) (Repository, error) {
-	projectRepos, err := c.getProjectRepos(projectId)
+// GetRepository fetches a single repository. A missing repository or project
+// yields an error matching ErrNotFound.
+func (c *Client) GetRepository(ctx context.Context, repositoryName, projectId string) (Repository, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint(fmt.Sprintf("%s/id:%s/repositories/%s", baseAPIEndpoint, projectId, repositoryName), repositoryFields.Query()), nil)
 	if err != nil {
 		return Repository{}, err
 	}
-	for _, repo := range projectRepos.Repos {
-		if repo.Name == repositoryName {
-			return repo, nil
-		}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return Repository{}, fmt.Errorf("repository %s: %w", repositoryName, err)
+	}
+
+	repository := Repository{}
+	err = json.Unmarshal(body, &repository)
+	if err != nil {
+		return Repository{}, err
 	}
-	return Repository{}, fmt.Errorf("repository %s not found", repositoryName)
+
+	return repository, nil
 }
 
-func (c *Client) CreateRepository(repositoryName string, projectId string, data CreateRepositoryData) (Repository, error) {
+// ListRepositories lists every repository in a project.
+func (c *Client) ListRepositories(ctx context.Context, projectId string) ([]Repository, error) {
+	projectRepos, err := c.getProjectRepos(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	return projectRepos.Repos, nil
+}
+
+// GetRepositoryURL returns the HTTP and SSH clone URLs of a repository.
+func (c *Client) GetRepositoryURL(ctx context.Context, repositoryName, projectId string) (RepositoryURL, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s/id:%s/repositories/%s/url", c.HostURL, baseAPIEndpoint, projectId, repositoryName), nil)
+	if err != nil {
+		return RepositoryURL{}, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return RepositoryURL{}, fmt.Errorf("problem getting clone URLs of repository %s: %w", repositoryName, err)
+	}
+
+	urls := RepositoryURL{}
+	err = json.Unmarshal(body, &urls)
+	if err != nil {
+		return RepositoryURL{}, err
+	}
+
+	return urls, nil
+}
+
+func (c *Client) CreateRepository(ctx context.Context, repositoryName string, projectId string, data CreateRepositoryData) (Repository, error) {
 	bytesData, _ := json.Marshal(data)
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/id:%s/repositories/%s", c.HostURL, baseAPIEndpoint, projectId, repositoryName), bytes.NewBuffer(bytesData))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s/id:%s/repositories/%s", c.HostURL, baseAPIEndpoint, projectId, repositoryName), bytes.NewBuffer(bytesData))
 	if err != nil {
 		return Repository{}, err
 	}
@@ -77,9 +119,9 @@ func (c *Client) CreateRepository(repositoryName string, projectId string, data
 	return repository, nil
 }
 
-func (c *Client) UpdateRepository(projectId, name string, data CreateRepositoryData) (Repository, error) {
+func (c *Client) UpdateRepository(ctx context.Context, projectId, name string, data CreateRepositoryData) (Repository, error) {
 	bytesData, _ := json.Marshal(data)
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/id:%s/repositories/%s/settings", c.HostURL, baseAPIEndpoint, projectId, name), bytes.NewBuffer(bytesData))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s/id:%s/repositories/%s/settings", c.HostURL, baseAPIEndpoint, projectId, name), bytes.NewBuffer(bytesData))
 	if err != nil {
 		return Repository{}, err
 	}
@@ -98,44 +140,44 @@ func (c *Client) UpdateRepository(projectId, name string, data CreateRepositoryD
 	return Repository, nil
 }
 
-func (c *Client) UpdateRepositoryDescription(projectId, name string, description string) (string, error) {
+func (c *Client) UpdateRepositoryDescription(ctx context.Context, projectId, name string, description string) (string, error) {
 	desc := map[string]string{
 		"description": description,
 	}
 	bytesData, _ := json.Marshal(desc)
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/id:%s/repositories/%s/description", c.HostURL, baseAPIEndpoint, projectId, name), bytes.NewBuffer(bytesData))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s/id:%s/repositories/%s/description", c.HostURL, baseAPIEndpoint, projectId, name), bytes.NewBuffer(bytesData))
 	if err != nil {
-		return "", fmt.Errorf("Problem!")
+		return "", fmt.Errorf("problem initiating request to update repository description: %w", err)
 	}
 
 	_, err = c.doRequest(req)
 	if err != nil {
-		return "", fmt.Errorf("Problem!2")
+		return "", fmt.Errorf("problem updating repository description: %w", err)
 	}
 
 	return description, nil
 }
 
-func (c *Client) UpdateRepositoryDefaultBranch(projectId, name string, branch string) (string, error) {
+func (c *Client) UpdateRepositoryDefaultBranch(ctx context.Context, projectId, name string, branch string) (string, error) {
 	desc := map[string]string{
 		"branch": branch,
 	}
 	bytesData, _ := json.Marshal(desc)
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/id:%s/repositories/%s/default-branch", c.HostURL, baseAPIEndpoint, projectId, name), bytes.NewBuffer(bytesData))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s/id:%s/repositories/%s/default-branch", c.HostURL, baseAPIEndpoint, projectId, name), bytes.NewBuffer(bytesData))
 	if err != nil {
-		return "", fmt.Errorf("Problem initiating request to update repository branch via API! " + err.Error())
+		return "", fmt.Errorf("problem initiating request to update repository branch: %w", err)
 	}
 
 	_, err = c.doRequest(req)
 	if err != nil {
-		return "", fmt.Errorf("Problem updating repository branch via API " + err.Error())
+		return "", fmt.Errorf("problem updating repository branch: %w", err)
 	}
 
 	return branch, nil
 }
 
-func (c *Client) DeleteRepository(projectId, repositoryName string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s%s/id:%s/repositories/%s", c.HostURL, baseAPIEndpoint, projectId, repositoryName), nil)
+func (c *Client) DeleteRepository(ctx context.Context, projectId, repositoryName string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s%s/id:%s/repositories/%s", c.HostURL, baseAPIEndpoint, projectId, repositoryName), nil)
 	if err != nil {
 		return err
 	}
@@ -148,7 +190,7 @@ func (c *Client) DeleteRepository(projectId, repositoryName string) error {
 	return nil
 }
 
-func (c *Client) DeleteRepositoryProtectedBranches(projectId, name string) error {
+func (c *Client) DeleteRepositoryProtectedBranches(ctx context.Context, projectId, name string) error {
 
 	settings := map[string]interface{}{
 		"settings": map[string]interface{}{
@@ -157,38 +199,38 @@ func (c *Client) DeleteRepositoryProtectedBranches(projectId, name string) error
 	}
 
 	bytesData, _ := json.Marshal(settings)
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/id:%s/repositories/%s/settings", c.HostURL, baseAPIEndpoint, projectId, name), bytes.NewBuffer(bytesData))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s/id:%s/repositories/%s/settings", c.HostURL, baseAPIEndpoint, projectId, name), bytes.NewBuffer(bytesData))
 	if err != nil {
-		return fmt.Errorf("Problem initiating request to deleted repository branch via API! " + err.Error())
+		return fmt.Errorf("problem initiating request to delete protected branches: %w", err)
 	}
 
 	_, err = c.doRequest(req)
 	if err != nil {
-		return fmt.Errorf("Problem getting response from protected branches " + err.Error())
+		return fmt.Errorf("problem deleting protected branches: %w", err)
 	}
 
 	return nil
 
 }
 
-func (c *Client) UpdateRepoProtectedBranches(data ProtectedBranchesPost, ProjectID string, Repository string) (ProtectedBranches, error) {
+func (c *Client) UpdateRepoProtectedBranches(ctx context.Context, data ProtectedBranchesPost, ProjectID string, Repository string) (ProtectedBranches, error) {
 
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return ProtectedBranches{}, fmt.Errorf("Problem converting request data to valid json")
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/id:%s/repositories/%s/settings", c.HostURL, baseAPIEndpoint, ProjectID, Repository), bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s/id:%s/repositories/%s/settings", c.HostURL, baseAPIEndpoint, ProjectID, Repository), bytes.NewBuffer(jsonData))
 	if err != nil {
-		return ProtectedBranches{}, fmt.Errorf("Problem initiating request to update repository branch via API! " + err.Error())
+		return ProtectedBranches{}, fmt.Errorf("problem initiating request to update repository branch: %w", err)
 	}
 	_, err = c.doRequest(req)
 	if err != nil {
-		return ProtectedBranches{}, fmt.Errorf("Problem getting response from protected branches (Updating) " + err.Error())
+		return ProtectedBranches{}, fmt.Errorf("problem updating protected branches: %w", err)
 
 	}
 	// API doesnt return validation. Run a get.
 
-	protected, err := c.GetRepoProtectedBranches(ProjectID, Repository)
+	protected, err := c.GetRepoProtectedBranches(ctx, ProjectID, Repository)
 	if err != nil {
 		return ProtectedBranches{}, err
 	}
@@ -197,15 +239,15 @@ func (c *Client) UpdateRepoProtectedBranches(data ProtectedBranchesPost, Project
 
 }
 
-func (c *Client) GetRepoProtectedBranches(ProjectID string, Repository string) (ProtectedBranches, error) {
+func (c *Client) GetRepoProtectedBranches(ctx context.Context, ProjectID string, Repository string) (ProtectedBranches, error) {
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s/id:%s/repositories/%s/settings?$fields=protectedBranches(allowCreate,allowDelete,allowForcePush,allowPush,pattern,qualityGate(approvals(approvedBy,minApprovals),automationJobs))", c.HostURL, baseAPIEndpoint, ProjectID, Repository), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint(fmt.Sprintf("%s/id:%s/repositories/%s/settings", baseAPIEndpoint, ProjectID, Repository), protectedBranchesFields.Query()), nil)
 	if err != nil {
-		return ProtectedBranches{}, fmt.Errorf("Problem setting up new http request; " + err.Error())
+		return ProtectedBranches{}, fmt.Errorf("problem setting up new http request: %w", err)
 	}
 	body, err := c.doRequest(req)
 	if err != nil {
-		return ProtectedBranches{}, fmt.Errorf("Problem getting repository branch settings via API! " + err.Error())
+		return ProtectedBranches{}, fmt.Errorf("problem getting repository branch settings: %w", err)
 	}
 
 	var protected ProtectedBranches
@@ -218,15 +260,15 @@ func (c *Client) GetRepoProtectedBranches(ProjectID string, Repository string) (
 
 }
 
-func (c *Client) GetJobName(ProjectID string, JobID string) (string, error) {
+func (c *Client) GetJobName(ctx context.Context, ProjectID string, JobID string) (string, error) {
 	// /api/http/projects/automation/jobs/{jobId}
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s/automation/jobs/%s?project=id:%s", c.HostURL, baseAPIEndpoint, JobID, ProjectID), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s/automation/jobs/%s?project=id:%s", c.HostURL, baseAPIEndpoint, JobID, ProjectID), nil)
 	if err != nil {
-		return "", fmt.Errorf("Problem setting up new http request; " + err.Error())
+		return "", fmt.Errorf("problem setting up new http request: %w", err)
 	}
 	body, err := c.doRequest(req)
 	if err != nil {
-		return "", fmt.Errorf("Problem getting repository branch settings via API! " + err.Error())
+		return "", fmt.Errorf("problem getting automation job %s: %w", JobID, err)
 	}
 
 	var automationJobs AutomationJobs
@@ -239,29 +281,22 @@ func (c *Client) GetJobName(ProjectID string, JobID string) (string, error) {
 
 }
 
-func (c *Client) GetJobIDFromName(ProjectID string, Repository string, Branch string, JobName string) (string, error) {
-
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s/id:%s/automation/jobs?repoFilter=%s&branchFilter=%s", c.HostURL, baseAPIEndpoint, ProjectID, Repository, Branch), nil)
-	if err != nil {
-		return "", fmt.Errorf("Problem setting up new http request; " + err.Error())
+func (c *Client) GetJobIDFromName(ctx context.Context, ProjectID string, Repository string, Branch string, JobName string) (string, error) {
+	query := url.Values{
+		"repoFilter":   {Repository},
+		"branchFilter": {Branch},
 	}
-	body, err := c.doRequest(req)
+	jobs, err := listAll[AutomationJobs](ctx, c, fmt.Sprintf("%s/id:%s/automation/jobs", baseAPIEndpoint, ProjectID), query)
 	if err != nil {
-		return "", fmt.Errorf("Problem getting repository branch settings via API! " + req.URL.Path + " " + err.Error())
-	}
-
-	var automationJobs AllAutomationJobs
-	err = json.Unmarshal(body, &automationJobs)
-	if err != nil {
-		return "", err
+		return "", fmt.Errorf("problem listing automation jobs: %w", err)
 	}
 
-	for _, job := range automationJobs.Data {
+	for _, job := range jobs {
 		if job.Name == JobName {
 			return job.Id, nil
 		}
 	}
 
-	return "", fmt.Errorf("Could not find Job ID matching name; " + JobName)
+	return "", fmt.Errorf("could not find job ID matching name %s: %w", JobName, ErrNotFound)
 
 }
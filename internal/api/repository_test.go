@@ -0,0 +1,153 @@
+package jetbrains_space_api_client_go_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	space "terraform-provider-jetbrains-space/internal/api"
+)
+
+func TestRepositoryLifecycle(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := context.Background()
+	p := s.AddProject("APP", "App")
+
+	_, err := c.CreateRepository(ctx, "backend", p.ID, space.CreateRepositoryData{Description: "API", DefaultBranch: "develop", Initialize: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateRepository(ctx, "backend", p.ID, space.CreateRepositoryData{}); !space.IsConflict(err) {
+		t.Errorf("creating a duplicate repository: got %v, want a 409", err)
+	}
+
+	repo, err := c.GetRepository(ctx, "backend", p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.Description != "API" || repo.DefaultBranch.Head != "refs/heads/develop" || repo.State != "Ready" {
+		t.Errorf("GetRepository = %+v", repo)
+	}
+
+	if _, err := c.UpdateRepositoryDescription(ctx, p.ID, "backend", "Service API"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UpdateRepositoryDefaultBranch(ctx, p.ID, "backend", "main"); err != nil {
+		t.Fatal(err)
+	}
+	repo, err = c.GetRepository(ctx, "backend", p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.Description != "Service API" || repo.DefaultBranch.Head != "refs/heads/main" {
+		t.Errorf("GetRepository after update = %+v", repo)
+	}
+
+	urls, err := c.GetRepositoryURL(ctx, "backend", p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(urls.HTTPURL, "/APP/backend.git") || !strings.HasPrefix(urls.SSHURL, "ssh://") {
+		t.Errorf("GetRepositoryURL = %+v", urls)
+	}
+
+	if err := c.DeleteRepository(ctx, p.ID, "backend"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetRepository(ctx, "backend", p.ID); !space.IsNotFound(err) {
+		t.Errorf("GetRepository after delete: got %v, want a 404", err)
+	}
+}
+
+func TestListRepositories(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := context.Background()
+	p := s.AddProject("APP", "App")
+
+	for _, name := range []string{"web", "api", "docs"} {
+		if _, err := c.CreateRepository(ctx, name, p.ID, space.CreateRepositoryData{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repos, err := c.ListRepositories(ctx, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, repo := range repos {
+		names = append(names, repo.Name)
+	}
+	if got, want := strings.Join(names, ","), "api,docs,web"; got != want {
+		t.Errorf("ListRepositories = %s, want %s", got, want)
+	}
+}
+
+func TestProtectedBranches(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := context.Background()
+	p := s.AddProject("APP", "App")
+	if _, err := c.CreateRepository(ctx, "app", p.ID, space.CreateRepositoryData{}); err != nil {
+		t.Fatal(err)
+	}
+
+	branches, err := c.UpdateRepoProtectedBranches(ctx, space.ProtectedBranchesPost{
+		Settings: space.ProtectedBranchesSettings{
+			ProtectedBranches: []space.ProtectedBranchesReq{{
+				Pattern: []string{"main", "release/*"},
+				QualityGate: space.ProtectedBranchesQualityGate{
+					Approvals: []space.ProtectedBranchesResultApprovals{{ApprovedBy: []string{"alice"}, MinApprovals: 1}},
+				},
+			}},
+		},
+	}, p.ID, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(branches.ProtectedBranches) != 1 || len(branches.ProtectedBranches[0].QualityGate.Approvals) != 1 {
+		t.Fatalf("UpdateRepoProtectedBranches = %+v", branches)
+	}
+
+	if err := c.DeleteRepositoryProtectedBranches(ctx, p.ID, "app"); err != nil {
+		t.Fatal(err)
+	}
+	branches, err = c.GetRepoProtectedBranches(ctx, p.ID, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(branches.ProtectedBranches) != 0 {
+		t.Errorf("protected branches after delete = %+v, want none", branches.ProtectedBranches)
+	}
+}
+
+func TestAutomationJobs(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := context.Background()
+	p := s.AddProject("APP", "App")
+	other := s.AddProject("OTHER", "Other")
+	build := s.AddAutomationJob(p.ID, "app", "main", "build")
+	s.AddAutomationJob(p.ID, "app", "main", "deploy")
+	s.AddAutomationJob(other.ID, "app", "main", "build")
+
+	name, err := c.GetJobName(ctx, p.ID, build)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "build" {
+		t.Errorf("GetJobName = %q, want build", name)
+	}
+
+	// Force several pages so the lookup has to follow them.
+	c.PageSize = 1
+	id, err := c.GetJobIDFromName(ctx, p.ID, "app", "main", "build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != build {
+		t.Errorf("GetJobIDFromName = %q, want %q", id, build)
+	}
+
+	if _, err := c.GetJobIDFromName(ctx, p.ID, "app", "main", "lint"); !space.IsNotFound(err) {
+		t.Errorf("looking up a missing job: got %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,89 @@
+package jetbrains_space_api_client_go
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// isRetryable reports whether a request is safe to send more than once.
+// Reads are always idempotent; of the writes only the repository settings
+// endpoint is, since it replaces the whole settings document.
+func isRetryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		return strings.HasSuffix(req.URL.Path, "/settings")
+	}
+	return false
+}
+
+// shouldRetry reports whether a failed attempt is worth repeating.
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		// Transport errors (connection resets, timeouts of a single attempt).
+		return true
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns how long to wait before the given retry attempt (starting
+// at 0), preferring the server's Retry-After header when present.
+func (c *Client) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			if c.RetryWaitMax > 0 && wait > c.RetryWaitMax {
+				return c.RetryWaitMax
+			}
+			return wait
+		}
+	}
+
+	wait := c.RetryWaitMin << attempt
+	if wait <= 0 || (c.RetryWaitMax > 0 && wait > c.RetryWaitMax) {
+		wait = c.RetryWaitMax
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	// Equal jitter: keep half of the delay and randomise the rest so parallel
+	// resources don't retry in lock step.
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+// parseRetryAfter understands both forms of the header: delay-seconds and an
+// HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
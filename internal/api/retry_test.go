@@ -0,0 +1,162 @@
+package jetbrains_space_api_client_go
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+		// slack allows for the clock moving on between formatting a date and
+		// parsing it.
+		slack time.Duration
+	}{
+		{name: "missing", value: ""},
+		{name: "seconds", value: "7", want: 7 * time.Second, wantOK: true},
+		{name: "zero seconds", value: "0", want: 0, wantOK: true},
+		{name: "negative seconds", value: "-3"},
+		{name: "garbage", value: "soon"},
+		{
+			name:   "future date",
+			value:  time.Now().Add(20 * time.Second).UTC().Format(http.TimeFormat),
+			want:   20 * time.Second,
+			wantOK: true,
+			slack:  2 * time.Second,
+		},
+		{
+			name:   "past date",
+			value:  time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat),
+			want:   0,
+			wantOK: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tc.value)
+			if ok != tc.wantOK {
+				t.Fatalf("parseRetryAfter(%q) ok = %v, want %v", tc.value, ok, tc.wantOK)
+			}
+			if got > tc.want || got < tc.want-tc.slack {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v (-%v)", tc.value, got, tc.want, tc.slack)
+			}
+		})
+	}
+}
+
+func TestBackoffHonoursRetryAfter(t *testing.T) {
+	c := &Client{RetryWaitMin: time.Second, RetryWaitMax: 10 * time.Second}
+
+	cases := []struct {
+		name       string
+		retryAfter string
+		want       time.Duration
+	}{
+		{name: "below cap", retryAfter: "4", want: 4 * time.Second},
+		{name: "capped by RetryWaitMax", retryAfter: "120", want: 10 * time.Second},
+		{name: "capped date", retryAfter: time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), want: 10 * time.Second},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{"Retry-After": {tc.retryAfter}}}
+			if got := c.backoff(5, res); got != tc.want {
+				t.Errorf("backoff with Retry-After %q = %v, want %v", tc.retryAfter, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	c := &Client{RetryWaitMin: time.Second, RetryWaitMax: 10 * time.Second}
+
+	cases := []struct {
+		attempt int
+		// base is the un-jittered delay; the result must lie in [base/2, base].
+		base time.Duration
+	}{
+		{attempt: 0, base: time.Second},
+		{attempt: 1, base: 2 * time.Second},
+		{attempt: 3, base: 8 * time.Second},
+	}
+	// Past the cap, however large the shift, the delay stays capped.
+	for attempt := 4; attempt <= 100; attempt++ {
+		cases = append(cases, struct {
+			attempt int
+			base    time.Duration
+		}{attempt, 10 * time.Second})
+	}
+
+	for _, tc := range cases {
+		for i := 0; i < 200; i++ {
+			got := c.backoff(tc.attempt, &http.Response{Header: http.Header{}})
+			if got < tc.base/2 || got > tc.base {
+				t.Fatalf("backoff(%d) = %v, want within [%v, %v]", tc.attempt, got, tc.base/2, tc.base)
+			}
+		}
+	}
+}
+
+func TestBackoffWithoutWaits(t *testing.T) {
+	c := &Client{}
+	if got := c.backoff(2, nil); got != 0 {
+		t.Errorf("backoff with no wait bounds = %v, want 0", got)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{http.MethodGet, "/api/http/projects", true},
+		{http.MethodHead, "/api/http/projects/id:1", true},
+		{http.MethodPost, "/api/http/projects/id:1/repositories/app/settings", true},
+		{http.MethodPost, "/api/http/projects", false},
+		{http.MethodPost, "/api/http/projects/id:1/people/members/update", false},
+		{http.MethodPost, "/api/http/projects/id:1/repositories/app", false},
+		{http.MethodPost, "/api/http/projects/id:1/repositories/app/description", false},
+		{http.MethodPost, "/api/http/projects/id:1/archive", false},
+		{http.MethodPatch, "/api/http/projects/id:1", false},
+		{http.MethodPut, "/uploads/abc", false},
+		{http.MethodDelete, "/api/http/projects/id:1", false},
+	}
+
+	for _, tc := range cases {
+		req, err := http.NewRequest(tc.method, "https://example.jetbrains.space"+tc.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := isRetryable(req); got != tc.want {
+			t.Errorf("isRetryable(%s %s) = %v, want %v", tc.method, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	cases := []struct {
+		status int
+		want   bool
+	}{
+		{http.StatusTooManyRequests, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+		{http.StatusInternalServerError, false},
+		{http.StatusNotFound, false},
+		{http.StatusUnauthorized, false},
+		{http.StatusBadRequest, false},
+	}
+
+	for _, tc := range cases {
+		if got := shouldRetry(&http.Response{StatusCode: tc.status}, nil); got != tc.want {
+			t.Errorf("shouldRetry(%d) = %v, want %v", tc.status, got, tc.want)
+		}
+	}
+}
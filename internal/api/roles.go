@@ -0,0 +1,179 @@
+package jetbrains_space_api_client_go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+var (
+	projectRoleFields = Fields{
+		F("id"),
+		F("key"),
+		F("name"),
+		F("permissions"),
+	}
+
+	projectPeopleFields = Fields{
+		F("profiles", F("profile", F("username")), F("roles", F("key"))),
+		F("teams", F("team", F("name")), F("roles", F("key"))),
+	}
+)
+
+func projectRolesPath(projectID string) string {
+	return fmt.Sprintf("%s/id:%s/access/roles", baseAPIEndpoint, projectID)
+}
+
+func (c *Client) GetProjectRole(ctx context.Context, projectID, roleID string) (ProjectRole, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint(fmt.Sprintf("%s/id:%s", projectRolesPath(projectID), roleID), projectRoleFields.Query()), nil)
+	if err != nil {
+		return ProjectRole{}, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return ProjectRole{}, err
+	}
+
+	role := ProjectRole{}
+	err = json.Unmarshal(body, &role)
+	if err != nil {
+		return ProjectRole{}, err
+	}
+
+	return role, nil
+}
+
+// CreateProjectRole defines a custom role in a project. The returned role
+// carries the key to grant it with.
+func (c *Client) CreateProjectRole(ctx context.Context, projectID string, data CreateProjectRoleData) (ProjectRole, error) {
+	data.Permissions = nonNilRoles(data.Permissions)
+	bytesData, _ := json.Marshal(data)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint(projectRolesPath(projectID), projectRoleFields.Query()), bytes.NewBuffer(bytesData))
+	if err != nil {
+		return ProjectRole{}, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return ProjectRole{}, fmt.Errorf("problem creating role %s in project %s: %w", data.Name, projectID, err)
+	}
+
+	role := ProjectRole{}
+	err = json.Unmarshal(body, &role)
+	if err != nil {
+		return ProjectRole{}, err
+	}
+
+	return role, nil
+}
+
+// UpdateProjectRole renames a custom role and replaces its permissions.
+func (c *Client) UpdateProjectRole(ctx context.Context, projectID, roleID string, data CreateProjectRoleData) (ProjectRole, error) {
+	data.Permissions = nonNilRoles(data.Permissions)
+	bytesData, _ := json.Marshal(data)
+	req, err := http.NewRequestWithContext(ctx, "PATCH", c.endpoint(fmt.Sprintf("%s/id:%s", projectRolesPath(projectID), roleID), projectRoleFields.Query()), bytes.NewBuffer(bytesData))
+	if err != nil {
+		return ProjectRole{}, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return ProjectRole{}, fmt.Errorf("problem updating role %s in project %s: %w", roleID, projectID, err)
+	}
+
+	role := ProjectRole{}
+	err = json.Unmarshal(body, &role)
+	if err != nil {
+		return ProjectRole{}, err
+	}
+
+	return role, nil
+}
+
+func (c *Client) DeleteProjectRole(ctx context.Context, projectID, roleID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint(fmt.Sprintf("%s/id:%s", projectRolesPath(projectID), roleID), nil), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetProjectPeople lists the profiles and teams in a project together with
+// the keys of every role they hold, custom roles included.
+func (c *Client) GetProjectPeople(ctx context.Context, projectID string) (ProjectPeople, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint(fmt.Sprintf("%s/id:%s/access/people", baseAPIEndpoint, projectID), projectPeopleFields.Query()), nil)
+	if err != nil {
+		return ProjectPeople{}, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return ProjectPeople{}, err
+	}
+
+	people := ProjectPeople{}
+	err = json.Unmarshal(body, &people)
+	if err != nil {
+		return ProjectPeople{}, err
+	}
+
+	return people, nil
+}
+
+// ProfileRoles returns the keys of the roles a profile holds.
+func (p ProjectPeople) ProfileRoles(username string) []string {
+	var roles []string
+	for _, profile := range p.Profiles {
+		if profile.Profile.Username == username {
+			for _, role := range profile.Roles {
+				roles = append(roles, role.Key)
+			}
+		}
+	}
+	return roles
+}
+
+// TeamRoles returns the keys of the roles a team holds.
+func (p ProjectPeople) TeamRoles(team string) []string {
+	var roles []string
+	for _, t := range p.Teams {
+		if t.Team.Name == team {
+			for _, role := range t.Roles {
+				roles = append(roles, role.Key)
+			}
+		}
+	}
+	return roles
+}
+
+// WithRole returns the usernames and team names holding the given role.
+func (p ProjectPeople) WithRole(key string) (profiles, teams []string) {
+	for _, profile := range p.Profiles {
+		for _, role := range profile.Roles {
+			if role.Key == key {
+				profiles = append(profiles, profile.Profile.Username)
+				break
+			}
+		}
+	}
+	for _, t := range p.Teams {
+		for _, role := range t.Roles {
+			if role.Key == key {
+				teams = append(teams, t.Team.Name)
+				break
+			}
+		}
+	}
+	return profiles, teams
+}
@@ -0,0 +1,55 @@
+package jetbrains_space_api_client_go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const uploadsEndpoint = "/api/http/uploads"
+
+// UploadFile stores content in Space and returns the attachment ID that
+// other endpoints, such as SetProjectIcon, refer to it by.
+//
+// Space uploads take two steps: the API hands out an upload path, and the
+// file is then PUT to that path on the organization's host.
+func (c *Client) UploadFile(ctx context.Context, filename, mediaType string, content []byte) (string, error) {
+	bytesData, _ := json.Marshal(map[string]string{
+		"storagePrefix": "file",
+		"mediaType":     mediaType,
+	})
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint(uploadsEndpoint, nil), bytes.NewBuffer(bytesData))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return "", fmt.Errorf("problem requesting upload path: %w", err)
+	}
+
+	var uploadPath string
+	if err := json.Unmarshal(body, &uploadPath); err != nil {
+		return "", fmt.Errorf("problem decoding upload path: %w", err)
+	}
+
+	req, err = http.NewRequestWithContext(ctx, "PUT", c.endpoint(strings.TrimRight(uploadPath, "/")+"/"+url.PathEscape(filename), nil), bytes.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+	if mediaType != "" {
+		req.Header.Set("Content-Type", mediaType)
+	}
+
+	body, err = c.doRequest(req)
+	if err != nil {
+		return "", fmt.Errorf("problem uploading %s: %w", filename, err)
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
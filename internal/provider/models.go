@@ -17,8 +17,15 @@ type jetbrainsSpaceProviderModel struct {
 	// version is set to the provider version on release, "dev" when the
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
-	Host  types.String `tfsdk:"host"`
-	Token types.String `tfsdk:"token"`
+	Host                  types.String  `tfsdk:"host"`
+	Token                 types.String  `tfsdk:"token"`
+	ClientID              types.String  `tfsdk:"client_id"`
+	ClientSecret          types.String  `tfsdk:"client_secret"`
+	Scope                 types.String  `tfsdk:"scope"`
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	RetryMaxWait          types.Int64   `tfsdk:"retry_max_wait"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
 }
 
 // Repo Resources.
@@ -54,6 +61,28 @@ type repoSettingsBranchModelJobs struct {
 
 // Project Resources.
 type projectResourceModel struct {
+	Name           types.String                 `tfsdk:"name"`
+	Key            types.String                 `tfsdk:"key"`
+	ID             types.String                 `tfsdk:"id"`
+	LastUpdated    types.String                 `tfsdk:"last_updated"`
+	Protected      types.Bool                   `tfsdk:"protected"`
+	Description    types.String                 `tfsdk:"description"`
+	Private        types.Bool                   `tfsdk:"private"`
+	Icon           types.String                 `tfsdk:"icon"`
+	Archived       types.Bool                   `tfsdk:"archived"`
+	DeletionPolicy types.String                 `tfsdk:"deletion_policy"`
+	MembershipMode types.String                 `tfsdk:"membership_mode"`
+	CreatedAt      types.String                 `tfsdk:"created_at"`
+	LatestActivity types.String                 `tfsdk:"latest_repository_activity"`
+	MemberTeams    []types.String               `tfsdk:"member_teams"`
+	Members        []types.String               `tfsdk:"members"`
+	AdminTeams     []types.String               `tfsdk:"admin_teams"`
+	Admins         []types.String               `tfsdk:"admins"`
+	Roles          []projectRoleMembershipModel `tfsdk:"roles"`
+}
+
+// projectResourceModelV0 decodes state written with projectSchemaV0.
+type projectResourceModelV0 struct {
 	Name        types.String   `tfsdk:"name"`
 	Key         types.String   `tfsdk:"key"`
 	ID          types.String   `tfsdk:"id"`
@@ -65,14 +94,90 @@ type projectResourceModel struct {
 	Admins      []types.String `tfsdk:"admins"`
 }
 
+// projectRoleMembershipModel - one entry of the roles attribute of a project.
+type projectRoleMembershipModel struct {
+	Role    types.String   `tfsdk:"role"`
+	Members []types.String `tfsdk:"members"`
+	Teams   []types.String `tfsdk:"teams"`
+}
+
+// Project Role Resources.
+type projectRoleResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	ProjectID   types.String   `tfsdk:"project_id"`
+	Name        types.String   `tfsdk:"name"`
+	Key         types.String   `tfsdk:"key"`
+	Permissions []types.String `tfsdk:"permissions"`
+}
+
+// Project Member and Team Member Resources. The principal attribute is named
+// after the kind of principal, so the model is read attribute by attribute.
+type projectMemberResourceModel struct {
+	ID        types.String
+	ProjectID types.String
+	Principal types.String
+	Roles     []types.String
+}
+
+// singleProjectDataSourceModel - the jetbrainsspace_project data source.
+type singleProjectDataSourceModel struct {
+	ID             types.String                 `tfsdk:"id"`
+	Key            types.String                 `tfsdk:"key"`
+	Name           types.String                 `tfsdk:"name"`
+	Description    types.String                 `tfsdk:"description"`
+	Private        types.Bool                   `tfsdk:"private"`
+	Archived       types.Bool                   `tfsdk:"archived"`
+	Icon           types.String                 `tfsdk:"icon"`
+	CreatedAt      types.String                 `tfsdk:"created_at"`
+	LatestActivity types.String                 `tfsdk:"latest_repository_activity"`
+	Members        []types.String               `tfsdk:"members"`
+	MemberTeams    []types.String               `tfsdk:"member_teams"`
+	Admins         []types.String               `tfsdk:"admins"`
+	AdminTeams     []types.String               `tfsdk:"admin_teams"`
+	Roles          []projectRoleMembershipModel `tfsdk:"roles"`
+}
+
 // ProjectDataSourceModel - Top level.
 type ProjectDataSourceModel struct {
-	Projects []ProjectsModel `tfsdk:"projects"`
+	NameRegex  types.String    `tfsdk:"name_regex"`
+	KeyPrefix  types.String    `tfsdk:"key_prefix"`
+	Archived   types.Bool      `tfsdk:"archived"`
+	Private    types.Bool      `tfsdk:"private"`
+	MemberTeam types.String    `tfsdk:"member_team"`
+	Starred    types.Bool      `tfsdk:"starred"`
+	Projects   []ProjectsModel `tfsdk:"projects"`
 }
 
 // ProjectsModel - Sub attrs of ProjectDataSourceModel.
 type ProjectsModel struct {
-	Key  types.String `tfsdk:"key"`
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	Key            types.String   `tfsdk:"key"`
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	CreatedAt      types.String   `tfsdk:"created_at"`
+	LatestActivity types.String   `tfsdk:"latest_repository_activity"`
+	Description    types.String   `tfsdk:"description"`
+	Archived       types.Bool     `tfsdk:"archived"`
+	Private        types.Bool     `tfsdk:"private"`
+	Admins         []types.String `tfsdk:"admins"`
+	MemberTeams    []types.String `tfsdk:"member_teams"`
+}
+
+// repositoriesDataSourceModel - the jetbrainsspace_repositories data source.
+type repositoriesDataSourceModel struct {
+	ProjectID    types.String        `tfsdk:"project_id"`
+	NameRegex    types.String        `tfsdk:"name_regex"`
+	State        types.String        `tfsdk:"state"`
+	Repositories []repositoriesModel `tfsdk:"repositories"`
+}
+
+// repositoriesModel - Sub attrs of repositoriesDataSourceModel.
+type repositoriesModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Description       types.String `tfsdk:"description"`
+	State             types.String `tfsdk:"state"`
+	DefaultBranchHead types.String `tfsdk:"default_branch_head"`
+	DefaultBranchRef  types.String `tfsdk:"default_branch_ref"`
+	HTTPURL           types.String `tfsdk:"http_url"`
+	SSHURL            types.String `tfsdk:"ssh_url"`
 }
@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	space "terraform-provider-jetbrains-space/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                     = &singleProjectDataSource{}
+	_ datasource.DataSourceWithConfigure        = &singleProjectDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &singleProjectDataSource{}
+)
+
+// NewProjectDataSource is a helper function to simplify the provider implementation.
+func NewProjectDataSource() datasource.DataSource {
+	return &singleProjectDataSource{}
+}
+
+// singleProjectDataSource looks up one project by ID or key.
+type singleProjectDataSource struct {
+	client *space.Client
+}
+
+func (d *singleProjectDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (d *singleProjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single project by `id` or `key`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the project. Exactly one of `id` and `key` must be set.",
+			},
+			"key": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Key of the project. Exactly one of `id` and `key` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Computed: true,
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+			},
+			"private": schema.BoolAttribute{
+				Computed: true,
+			},
+			"archived": schema.BoolAttribute{
+				Computed: true,
+			},
+			"icon": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the uploaded icon. Null if the project has none.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the project was created, in RFC 3339 format.",
+			},
+			"latest_repository_activity": schema.StringAttribute{
+				Computed:    true,
+				Description: "When any repository of the project last changed, in RFC 3339 format. Null if the project has no repository activity.",
+			},
+			"members": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"member_teams": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"admins": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"admin_teams": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"roles": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Grants of custom roles, one entry per role key.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Computed: true,
+						},
+						"members": schema.SetAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"teams": schema.SetAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *singleProjectDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		exactlyOneOfValidator{attributes: []string{"id", "key"}},
+	}
+}
+
+func (d *singleProjectDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*space.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *space.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *singleProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state singleProjectDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var project space.Project
+	var err error
+	if !state.ID.IsNull() {
+		project, err = d.client.GetProject(ctx, state.ID.ValueString())
+	} else {
+		project, err = d.client.GetProjectByKey(ctx, state.Key.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Project",
+			err.Error(),
+		)
+		return
+	}
+
+	people, err := d.client.GetProjectPeople(ctx, project.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Project People",
+			err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(project.ID)
+	state.Key = types.StringValue(project.Key.Key)
+	state.Name = types.StringValue(project.Name)
+	state.Description = types.StringValue(project.Description)
+	state.Private = types.BoolValue(project.Private)
+	state.Archived = types.BoolValue(project.Archived)
+	state.Icon = types.StringNull()
+	if project.Icon != "" {
+		state.Icon = types.StringValue(project.Icon)
+	}
+	state.CreatedAt = dateTimeValue(&project.CreatedAt)
+	state.LatestActivity = dateTimeValue(project.LatestRepositoryActivity)
+
+	// Flatten memberships the way the project resource does, reporting
+	// everyone: empty sets make it list every principal of each role.
+	access := projectResourceModel{
+		MemberTeams: []types.String{},
+		Members:     []types.String{},
+		AdminTeams:  []types.String{},
+		Admins:      []types.String{},
+		Roles:       []projectRoleMembershipModel{},
+	}
+	for _, role := range customRoleKeys(people) {
+		access.Roles = append(access.Roles, projectRoleMembershipModel{
+			Role:    types.StringValue(role),
+			Members: []types.String{},
+			Teams:   []types.String{},
+		})
+	}
+	access, err = FetchUpdatedAccessForProject(access, project, people, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Project Access",
+			err.Error(),
+		)
+		return
+	}
+	state.MemberTeams = access.MemberTeams
+	state.Members = access.Members
+	state.AdminTeams = access.AdminTeams
+	state.Admins = access.Admins
+	state.Roles = access.Roles
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// customRoleKeys lists, in order, the keys of every role other than the
+// built-in ones that someone in the project holds.
+func customRoleKeys(people space.ProjectPeople) []string {
+	seen := map[string]bool{"admin": true, "member": true}
+	var keys []string
+	add := func(roles []space.ProjectRoleKey) {
+		for _, role := range roles {
+			if !seen[role.Key] {
+				seen[role.Key] = true
+				keys = append(keys, role.Key)
+			}
+		}
+	}
+	for _, profile := range people.Profiles {
+		add(profile.Roles)
+	}
+	for _, team := range people.Teams {
+		add(team.Roles)
+	}
+	sort.Strings(keys)
+	return keys
+}
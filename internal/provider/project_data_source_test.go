@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProjectDataSource(t *testing.T) {
+	s := newTestServer(t)
+	project := s.AddProject("WEB", "Storefront")
+	project.Description = "Online shop"
+	project.Members["alice"] = map[string]bool{"admin": true}
+	project.Members["bob"] = map[string]bool{"member": true, "reviewer": true}
+	project.Teams["Backend"] = map[string]bool{"member": true}
+	project.Teams["QA"] = map[string]bool{"reviewer": true}
+	s.AddRole(project.ID, "reviewer")
+
+	check := resource.ComposeAggregateTestCheckFunc(
+		resource.TestCheckResourceAttr("data.jetbrainsspace_project.test", "id", project.ID),
+		resource.TestCheckResourceAttr("data.jetbrainsspace_project.test", "key", "WEB"),
+		resource.TestCheckResourceAttr("data.jetbrainsspace_project.test", "name", "Storefront"),
+		resource.TestCheckResourceAttr("data.jetbrainsspace_project.test", "description", "Online shop"),
+		resource.TestCheckResourceAttr("data.jetbrainsspace_project.test", "archived", "false"),
+		resource.TestCheckResourceAttr("data.jetbrainsspace_project.test", "admins.#", "1"),
+		resource.TestCheckTypeSetElemAttr("data.jetbrainsspace_project.test", "admins.*", "alice"),
+		resource.TestCheckResourceAttr("data.jetbrainsspace_project.test", "admin_teams.#", "0"),
+		resource.TestCheckResourceAttr("data.jetbrainsspace_project.test", "members.#", "1"),
+		resource.TestCheckTypeSetElemAttr("data.jetbrainsspace_project.test", "members.*", "bob"),
+		resource.TestCheckResourceAttr("data.jetbrainsspace_project.test", "member_teams.#", "1"),
+		resource.TestCheckTypeSetElemAttr("data.jetbrainsspace_project.test", "member_teams.*", "Backend"),
+		resource.TestCheckResourceAttr("data.jetbrainsspace_project.test", "roles.#", "1"),
+		resource.TestCheckResourceAttr("data.jetbrainsspace_project.test", "roles.0.role", "reviewer"),
+		resource.TestCheckTypeSetElemAttr("data.jetbrainsspace_project.test", "roles.0.members.*", "bob"),
+		resource.TestCheckTypeSetElemAttr("data.jetbrainsspace_project.test", "roles.0.teams.*", "QA"),
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Lookup by ID.
+			{
+				Config: s.ProviderConfig() + fmt.Sprintf(`
+data "jetbrainsspace_project" "test" {
+  id = %q
+}
+`, project.ID),
+				Check: check,
+			},
+			// Lookup by key.
+			{
+				Config: s.ProviderConfig() + `
+data "jetbrainsspace_project" "test" {
+  key = "WEB"
+}
+`,
+				Check: check,
+			},
+		},
+	})
+}
+
+func TestAccProjectDataSource_idOrKey(t *testing.T) {
+	s := newTestServer(t)
+	project := s.AddProject("WEB", "Storefront")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.ProviderConfig() + `
+data "jetbrainsspace_project" "test" {
+}
+`,
+				ExpectError: regexp.MustCompile(`Set exactly one of: id, key`),
+			},
+			{
+				Config: s.ProviderConfig() + fmt.Sprintf(`
+data "jetbrainsspace_project" "test" {
+  id  = %q
+  key = "WEB"
+}
+`, project.ID),
+				ExpectError: regexp.MustCompile(`Set exactly one of: id, key`),
+			},
+		},
+	})
+}
@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	space "terraform-provider-jetbrains-space/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &projectMemberResource{}
+	_ resource.ResourceWithConfigure   = &projectMemberResource{}
+	_ resource.ResourceWithImportState = &projectMemberResource{}
+)
+
+// principalKind describes who a project member resource grants roles to:
+// a profile or a team.
+type principalKind struct {
+	// typeName is appended to the provider type name.
+	typeName string
+	// attribute names the principal in the schema and import identifier.
+	attribute string
+	// noun is used in descriptions and messages.
+	noun        string
+	description string
+	roles       func(people space.ProjectPeople, name string) []string
+	setRoles    func(ctx context.Context, client *space.Client, projectID, name string, toAdd, toRemove []string) error
+}
+
+var profilePrincipal = principalKind{
+	typeName:    "_project_member",
+	attribute:   "username",
+	noun:        "profile",
+	description: "Username of the profile.",
+	roles:       space.ProjectPeople.ProfileRoles,
+	setRoles: func(ctx context.Context, client *space.Client, projectID, username string, toAdd, toRemove []string) error {
+		return client.SetProjectMembers(ctx, space.ProjectMembers{
+			Profile:     "username:" + username,
+			AddRoles:    toAdd,
+			RemoveRoles: toRemove,
+		}, projectID)
+	},
+}
+
+var teamPrincipal = principalKind{
+	typeName:    "_project_team_member",
+	attribute:   "team",
+	noun:        "team",
+	description: "Name of the team.",
+	roles:       space.ProjectPeople.TeamRoles,
+	setRoles: func(ctx context.Context, client *space.Client, projectID, team string, toAdd, toRemove []string) error {
+		return client.MapTeamToProjectRole(ctx, space.ProjectRoles{
+			Team:        "name:" + team,
+			AddRoles:    toAdd,
+			RemoveRoles: toRemove,
+		}, projectID)
+	},
+}
+
+// NewProjectMemberResource is a helper function to simplify the provider implementation.
+func NewProjectMemberResource() resource.Resource {
+	return &projectMemberResource{kind: profilePrincipal}
+}
+
+// NewProjectTeamMemberResource is a helper function to simplify the provider implementation.
+func NewProjectTeamMemberResource() resource.Resource {
+	return &projectMemberResource{kind: teamPrincipal}
+}
+
+// projectMemberResource manages the roles of one profile or team in one
+// project.
+type projectMemberResource struct {
+	client *space.Client
+	kind   principalKind
+}
+
+// Metadata returns the resource type name.
+func (r *projectMemberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.kind.typeName
+}
+
+func (r *projectMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Grants one " + r.kind.noun + " roles in a project. Use with `membership_mode = \"additive\"` on the project, or the project will remove the grant. " +
+			"Only the listed roles are managed: roles the " + r.kind.noun + " holds through other resources are left alone, and destroying this resource revokes only the listed roles, even where another resource grants them too.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			r.kind.attribute: schema.StringAttribute{
+				Required:    true,
+				Description: r.kind.description,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"roles": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Keys of the roles granted to the " + r.kind.noun + ": `admin`, `member`, or the `key` of a `jetbrainsspace_project_role`. " +
+					"This is the way to grant a custom role managed in the same configuration as its project.",
+			},
+		},
+	}
+}
+
+// Create a new resource.
+func (r *projectMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan, diags := r.getModel(ctx, req.Plan.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := plan.ProjectID.ValueString()
+	principal := plan.Principal.ValueString()
+	toAdd, _ := diffPrincipals(nil, plan.Roles)
+	err := r.setRoles(ctx, projectID, principal, toAdd, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error granting roles to "+principal+" in project "+projectID,
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(projectID + "," + principal)
+
+	resp.Diagnostics.Append(r.setModel(ctx, &resp.State, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *projectMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state, diags := r.getModel(ctx, req.State.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	people, err := r.client.GetProjectPeople(ctx, state.ProjectID.ValueString())
+	if space.IsNotFound(err) {
+		tflog.Warn(ctx, "Project not found, removing "+r.kind.noun+" from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Jetbrains Space project",
+			"Could not read project ID "+state.ProjectID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	roles := r.kind.roles(people, state.Principal.ValueString())
+	if len(roles) == 0 {
+		tflog.Warn(ctx, "No roles in project, removing "+r.kind.noun+" from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	// Roles granted elsewhere, such as through the project's admins, are not
+	// ours to revoke. Only an import, which has no roles yet, adopts them all.
+	state.Roles = reconcilePrincipals(state.Roles, roles, state.Roles == nil)
+
+	resp.Diagnostics.Append(r.setModel(ctx, &resp.State, state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *projectMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan, diags := r.getModel(ctx, req.Plan.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	state, diags := r.getModel(ctx, req.State.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := plan.ProjectID.ValueString()
+	principal := plan.Principal.ValueString()
+	toAdd, toRemove := diffPrincipals(state.Roles, plan.Roles)
+	err := r.setRoles(ctx, projectID, principal, toAdd, toRemove)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating roles of "+principal+" in project "+projectID,
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.setModel(ctx, &resp.State, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *projectMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state, diags := r.getModel(ctx, req.State.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, toRemove := diffPrincipals(state.Roles, nil)
+	err := r.setRoles(ctx, state.ProjectID.ValueString(), state.Principal.ValueString(), nil, toRemove)
+	if err != nil && !space.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error revoking roles of "+state.Principal.ValueString()+" in project "+state.ProjectID.ValueString(),
+			err.Error(),
+		)
+	}
+}
+
+func (r *projectMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, principal, ok := strings.Cut(req.ID, ",")
+	if !ok || projectID == "" || principal == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_id,%s. Got: %q", r.kind.attribute, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(r.kind.attribute), principal)...)
+}
+
+func (r *projectMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*space.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *space.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// getModel reads the model attribute by attribute, since the principal
+// attribute is named after the kind.
+func (r *projectMemberResource) getModel(ctx context.Context, get func(context.Context, path.Path, interface{}) diag.Diagnostics) (projectMemberResourceModel, diag.Diagnostics) {
+	var m projectMemberResourceModel
+	var diags diag.Diagnostics
+	diags.Append(get(ctx, path.Root("id"), &m.ID)...)
+	diags.Append(get(ctx, path.Root("project_id"), &m.ProjectID)...)
+	diags.Append(get(ctx, path.Root(r.kind.attribute), &m.Principal)...)
+	diags.Append(get(ctx, path.Root("roles"), &m.Roles)...)
+	return m, diags
+}
+
+func (r *projectMemberResource) setModel(ctx context.Context, state *tfsdk.State, m projectMemberResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Append(state.SetAttribute(ctx, path.Root("id"), m.ID)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("project_id"), m.ProjectID)...)
+	diags.Append(state.SetAttribute(ctx, path.Root(r.kind.attribute), m.Principal)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("roles"), m.Roles)...)
+	return diags
+}
+
+func (r *projectMemberResource) setRoles(ctx context.Context, projectID, principal string, toAdd, toRemove []string) error {
+	if len(toAdd) == 0 && len(toRemove) == 0 {
+		return nil
+	}
+	return r.kind.setRoles(ctx, r.client, projectID, principal, toAdd, toRemove)
+}
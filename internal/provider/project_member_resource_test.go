@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-jetbrains-space/internal/spacetest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccProjectMemberResource(t *testing.T) {
+	s := newTestServer(t)
+	project := s.AddProject("APP", "App")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if roles := s.Project(project.ID).Members["alice"]; len(roles) > 0 {
+				return fmt.Errorf("alice still holds roles %v", roles)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing.
+			{
+				Config: s.ProviderConfig() + fmt.Sprintf(`
+resource "jetbrainsspace_project_member" "test" {
+  project_id = %q
+  username   = "alice"
+  roles      = ["member", "admin"]
+}
+`, project.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project_member.test", "id", project.ID+",alice"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project_member.test", "roles.#", "2"),
+					testAccCheckPrincipalRoles(s, project.ID, false, "alice", "admin", "member"),
+				),
+			},
+			// ImportState testing.
+			{
+				ResourceName:      "jetbrainsspace_project_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing.
+			{
+				Config: s.ProviderConfig() + fmt.Sprintf(`
+resource "jetbrainsspace_project_member" "test" {
+  project_id = %q
+  username   = "alice"
+  roles      = ["member"]
+}
+`, project.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project_member.test", "roles.#", "1"),
+					testAccCheckPrincipalRoles(s, project.ID, false, "alice", "member"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase.
+		},
+	})
+}
+
+func TestAccProjectTeamMemberResource(t *testing.T) {
+	s := newTestServer(t)
+	project := s.AddProject("APP", "App")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if roles := s.Project(project.ID).Teams["Backend"]; len(roles) > 0 {
+				return fmt.Errorf("Backend still holds roles %v", roles)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: s.ProviderConfig() + fmt.Sprintf(`
+resource "jetbrainsspace_project_team_member" "test" {
+  project_id = %q
+  team       = "Backend"
+  roles      = ["member"]
+}
+`, project.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project_team_member.test", "id", project.ID+",Backend"),
+					testAccCheckPrincipalRoles(s, project.ID, true, "Backend", "member"),
+				),
+			},
+			{
+				ResourceName:      "jetbrainsspace_project_team_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: s.ProviderConfig() + fmt.Sprintf(`
+resource "jetbrainsspace_project_team_member" "test" {
+  project_id = %q
+  team       = "Backend"
+  roles      = ["admin"]
+}
+`, project.ID),
+				Check: testAccCheckPrincipalRoles(s, project.ID, true, "Backend", "admin"),
+			},
+		},
+	})
+}
+
+// A role granted by the project resource must survive a member resource that
+// manages a different role of the same profile.
+func TestAccProjectMemberResource_rolesGrantedElsewhere(t *testing.T) {
+	s := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name            = "App"
+  admins          = ["alice"]
+  membership_mode = "additive"
+}
+
+resource "jetbrainsspace_project_member" "test" {
+  project_id = jetbrainsspace_project.test.id
+  username   = "alice"
+  roles      = ["member"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project_member.test", "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("jetbrainsspace_project_member.test", "roles.*", "member"),
+					func(state *terraform.State) error {
+						id := state.RootModule().Resources["jetbrainsspace_project.test"].Primary.ID
+						return testAccCheckPrincipalRoles(s, id, false, "alice", "admin", "member")(state)
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectMemberResource_importInvalidID(t *testing.T) {
+	s := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project_team_member" "test" {
+  project_id = "unknown"
+  team       = "Backend"
+  roles      = ["member"]
+}
+`,
+				ResourceName:  "jetbrainsspace_project_team_member.test",
+				ImportState:   true,
+				ImportStateId: "Backend",
+				ExpectError:   regexp.MustCompile(`project_id,team`),
+			},
+		},
+	})
+}
+
+// testAccCheckPrincipalRoles verifies that a profile, or a team, holds exactly
+// the given roles in the project.
+func testAccCheckPrincipalRoles(s *spacetest.Server, projectID string, isTeam bool, name string, roles ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		project := s.Project(projectID)
+		if project == nil {
+			return fmt.Errorf("project %s not found in Space", projectID)
+		}
+		held := project.Members[name]
+		if isTeam {
+			held = project.Teams[name]
+		}
+		if len(held) != len(roles) {
+			return fmt.Errorf("%s holds roles %v, want %v", name, held, roles)
+		}
+		for _, role := range roles {
+			if !held[role] {
+				return fmt.Errorf("%s holds roles %v, want %v", name, held, roles)
+			}
+		}
+		return nil
+	}
+}
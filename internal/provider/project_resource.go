@@ -3,6 +3,10 @@ package provider
 import (
 	"context"
 	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -13,16 +17,32 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &projectResource{}
-	_ resource.ResourceWithConfigure   = &projectResource{}
-	_ resource.ResourceWithImportState = &projectResource{}
+	_ resource.Resource                 = &projectResource{}
+	_ resource.ResourceWithConfigure    = &projectResource{}
+	_ resource.ResourceWithImportState  = &projectResource{}
+	_ resource.ResourceWithUpgradeState = &projectResource{}
+)
+
+// Values accepted by the deletion_policy attribute.
+const (
+	deletionPolicyDelete  = "delete"
+	deletionPolicyArchive = "archive"
+	deletionPolicyAbandon = "abandon"
+)
+
+// Values accepted by the membership_mode attribute.
+const (
+	membershipModeAuthoritative = "authoritative"
+	membershipModeAdditive      = "additive"
 )
 
 // NewProjectResource is a helper function to simplify the provider implementation.
@@ -52,7 +72,25 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 
 	// Create new project.
 	projectName := plan.Name.ValueString()
-	project, err := r.client.CreateProject(projectName)
+	// An unset key is unknown at this point; derive one from the name.
+	projectKey := plan.Key.ValueString()
+	if plan.Key.IsUnknown() {
+		projectKey = space.DefaultProjectKey(projectName)
+		if projectKey == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("key"),
+				"Missing Project Key",
+				"Could not derive a project key from name "+plan.Name.String()+" because it contains no letters or digits. Set key explicitly.",
+			)
+			return
+		}
+	}
+	project, err := r.client.CreateProject(ctx, space.CreateProjectData{
+		Key:         space.ProjectKey{Key: projectKey},
+		Name:        projectName,
+		Description: plan.Description.ValueString(),
+		Private:     plan.Private.ValueBool(),
+	})
 	protected := plan.Protected.ValueBool()
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -62,68 +100,71 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	var toRemove []string // we dont remove on create.
-
-	err = r.UpdateProjectRoles(ctx, plan, project.ID, toRemove, true, "admin")
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error mapping teams to admin role in project"+project.ID,
-			err.Error(),
-		)
-		return
-	}
-	err = r.UpdateProjectRoles(ctx, plan, project.ID, toRemove, true, "member")
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error mapping teams to member role in project"+project.ID,
-			err.Error(),
-		)
-		return
-	}
-	err = r.UpdateProjectRoles(ctx, plan, project.ID, toRemove, false, "admin")
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error mapping user to admin role in project"+project.ID,
-			err.Error(),
-		)
-		return
+	if !plan.Icon.IsNull() {
+		err = r.SetProjectIcon(ctx, project.ID, plan.Icon.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("icon"),
+				"Error setting icon for project "+project.ID,
+				err.Error(),
+			)
+			return
+		}
 	}
-	err = r.UpdateProjectRoles(ctx, plan, project.ID, toRemove, false, "member")
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error mapping user to member role in project"+project.ID,
-			err.Error(),
-		)
-		return
+
+	for _, m := range projectMemberships(&plan) {
+		var names []string
+		for _, v := range *m.value {
+			names = append(names, v.ValueString())
+		}
+		err = r.AddProjectMembers(ctx, project.ID, names, m.isTeam, m.role)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				m.path,
+				"Error mapping "+m.path.String()+" to "+m.role+" role in project "+project.ID,
+				err.Error(),
+			)
+			return
+		}
 	}
+
 	// Call get project again to get updated project values.
 
-	p, err := r.client.GetProject(project.ID)
+	p, err := r.client.GetProject(ctx, project.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error getting project info.",
 			err.Error(),
 		)
+		return
 	}
 
-	plan, err = FetchUpdatedAccessForProject(plan, p)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error setting project access profiles to state.",
-			err.Error(),
-		)
-		return
+	// Memberships stay as planned; anything else Space added on its own is
+	// reconciled on the next Read, apart from the creator's admin grant.
+	if plan.Archived.ValueBool() {
+		err = r.client.ArchiveProject(ctx, project.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error archiving project "+project.ID,
+				err.Error(),
+			)
+			return
+		}
+		p.Archived = true
 	}
 
 	// Map response body to schema and populate Computed attribute values.
 	plan.Name = types.StringValue(project.Name)
 	plan.ID = types.StringValue(project.ID)
 	plan.Key = types.StringValue(project.Key.Key)
+	plan.Description = types.StringValue(p.Description)
+	plan.Private = types.BoolValue(p.Private)
+	plan.Archived = types.BoolValue(p.Archived)
+	plan.CreatedAt = dateTimeValue(&p.CreatedAt)
+	plan.LatestActivity = dateTimeValue(p.LatestRepositoryActivity)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	plan.Protected = types.BoolValue(protected)
 
-	//plan.Key = types.StringValue(project.Key.Key).
-
 	// Set state to fully populated data.
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -143,11 +184,17 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	// Get refreshed project values.
-	project, err := r.client.GetProject(state.ID.ValueString())
+	project, err := r.client.GetProject(ctx, state.ID.ValueString())
+	if space.IsNotFound(err) {
+		// Deleted outside of Terraform; drop it so the next plan re-creates it.
+		tflog.Warn(ctx, "Project not found, removing from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Jetbrains Space project",
-			"Could not read project3 ID "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read project ID "+state.ID.ValueString()+": "+err.Error(),
 		)
 		return
 	}
@@ -156,8 +203,49 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 	state.ID = types.StringValue(project.ID)
 	state.Name = types.StringValue(project.Name)
 	state.Key = types.StringValue(project.Key.Key)
+	state.Description = types.StringValue(project.Description)
+	state.Private = types.BoolValue(project.Private)
+	state.Archived = types.BoolValue(project.Archived)
+	state.CreatedAt = dateTimeValue(&project.CreatedAt)
+	state.LatestActivity = dateTimeValue(project.LatestRepositoryActivity)
+	if state.DeletionPolicy.IsNull() {
+		state.DeletionPolicy = types.StringValue(deletionPolicyDelete)
+	}
+	if state.MembershipMode.IsNull() {
+		state.MembershipMode = types.StringValue(membershipModeAuthoritative)
+	}
+	if project.Icon == "" && !state.Icon.IsNull() {
+		// The icon was removed in Space; forget the path so it's uploaded again.
+		state.Icon = types.StringNull()
+	}
+
+	var people space.ProjectPeople
+	if len(state.Roles) > 0 {
+		people, err = r.client.GetProjectPeople(ctx, project.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading roles of project "+project.ID,
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Space makes whoever creates a project an administrator of it, which
+	// authoritative mode would otherwise revoke on the next apply.
+	var creator string
+	if state.MembershipMode.ValueString() != membershipModeAdditive {
+		creator, err = r.client.CurrentUsername(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading the authenticated profile",
+				err.Error(),
+			)
+			return
+		}
+	}
 
-	state, err = FetchUpdatedAccessForProject(state, project)
+	state, err = FetchUpdatedAccessForProject(state, project, people, creator)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error setting project access profiles to state",
@@ -184,89 +272,113 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	var state projectResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Archived projects are read-only, so unarchive before changing anything,
+	// even when the project stays archived, and archive again only once every
+	// other change has been applied.
+	if state.Archived.ValueBool() {
+		err := r.client.UnarchiveProject(ctx, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error unarchiving Space project "+plan.ID.ValueString(),
+				err.Error(),
+			)
+			return
+		}
+	}
+
 	var project space.Project
 	project.Name = plan.Name.ValueString()
 	project.Key.Key = plan.Key.ValueString()
 	project.ID = plan.ID.ValueString()
+	project.Description = plan.Description.ValueString()
+	project.Private = plan.Private.ValueBool()
 	// Update project with plan values.
-	_, err := r.client.UpdateProject(project.ID, project)
+	_, err := r.client.UpdateProject(ctx, project.ID, project)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Updating Space project; "+project.ID+" is the value...",
+			"Error Updating Space project; "+project.ID,
 			"Could not update project, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
-	type MembersMap struct {
-		Remove    []string
-		Different bool
-		Team      bool
-		TeamType  string
+	iconChanged, _, err := CompareValues(ctx, path.Root("icon"), req.State, req.Plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Problem comparing state and plan",
+			err.Error(),
+		)
+		return
 	}
-	membersMap := []MembersMap{}
-
-	for _, v := range []string{"members", "member_teams", "admins", "admin_teams"} {
-		// Compare plan and state of project roles, so those not in plan can be removed.
-		diff, toRemove, err := CompareProjectRoles(ctx, path.Root(v), resp.State, req.Plan)
+	if iconChanged {
+		err = r.SetProjectIcon(ctx, project.ID, plan.Icon.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error comparing state and plan values for Project Roles;"+v+". Project ID; "+project.ID,
+			resp.Diagnostics.AddAttributeError(
+				path.Root("icon"),
+				"Error setting icon for project "+project.ID,
 				err.Error(),
 			)
 			return
 		}
+	}
 
-		var isTeam bool
-
-		var typeOfTeam string
-		if strings.HasSuffix(v, "_teams") {
-			isTeam = true
-		} else {
-			isTeam = false
+	// Memberships are matched by role and principal kind rather than position,
+	// so reordering or removing entries of roles only touches what changed.
+	priorMemberships := map[string]projectMembership{}
+	for _, m := range projectMemberships(&state) {
+		priorMemberships[m.key()] = m
+	}
+	for _, m := range projectMemberships(&plan) {
+		var prior []types.String
+		if p, ok := priorMemberships[m.key()]; ok {
+			prior = *p.value
+			delete(priorMemberships, m.key())
 		}
-		if strings.HasPrefix(v, "member") {
-			typeOfTeam = "member"
+		err = r.applyMembership(ctx, project.ID, m, prior, *m.value)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				m.path,
+				"Error updating "+m.path.String()+" in project; "+project.ID,
+				err.Error(),
+			)
+			return
 		}
-		if strings.HasPrefix(v, "admin") {
-			typeOfTeam = "admin"
+	}
+	for _, m := range projectMemberships(&state) {
+		if _, dropped := priorMemberships[m.key()]; !dropped {
+			continue
 		}
-
-		m := MembersMap{
-			Different: diff,
-			Remove:    toRemove,
-			Team:      isTeam,
-			TeamType:  typeOfTeam,
+		err = r.applyMembership(ctx, project.ID, m, *m.value, nil)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				m.path,
+				"Error removing "+m.path.String()+" from project; "+project.ID,
+				err.Error(),
+			)
+			return
 		}
-
-		membersMap = append(membersMap, m)
 	}
 
-	for _, v := range membersMap {
-		if v.Different {
-			if len(v.Remove) > 0 {
-				err = r.RemoveProjectMembers(ctx, plan, project.ID, v.Remove, v.Team, v.TeamType)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error removing members from project; "+project.ID,
-						err.Error(),
-					)
-					return
-				}
-			}
-			err = r.UpdateProjectRoles(ctx, plan, project.ID, v.Remove, v.Team, v.TeamType)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error updates members within project; "+project.ID,
-					err.Error(),
-				)
-				return
-			}
+	if plan.Archived.ValueBool() {
+		err = r.client.ArchiveProject(ctx, project.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error archiving Space project "+project.ID,
+				err.Error(),
+			)
+			return
 		}
 	}
 
 	// Fetch updated items from Project.
-	p, err := r.client.GetProject(project.ID)
+	p, err := r.client.GetProject(ctx, project.ID)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -276,18 +388,14 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	plan, err = FetchUpdatedAccessForProject(plan, p)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error setting project access profiles to state",
-			err.Error(),
-		)
-		return
-	}
-
 	plan.ID = types.StringValue(p.ID)
 	plan.Key = types.StringValue(p.Key.Key)
 	plan.Name = types.StringValue(p.Name)
+	plan.Description = types.StringValue(p.Description)
+	plan.Private = types.BoolValue(p.Private)
+	plan.Archived = types.BoolValue(p.Archived)
+	plan.CreatedAt = dateTimeValue(&p.CreatedAt)
+	plan.LatestActivity = dateTimeValue(p.LatestRepositoryActivity)
 
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	plan.Protected = types.BoolValue(plan.Protected.ValueBool())
@@ -310,16 +418,32 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	// Delete existing order.
 	if state.Protected.ValueBool() {
 		resp.Diagnostics.AddError(
 			"Project is protected, not deleting!",
 			"",
 		)
-	} else {
-		// Delete existing order.
-		err := r.client.DeleteProject(state.ID.ValueString())
-		if err != nil {
+		return
+	}
+
+	switch state.DeletionPolicy.ValueString() {
+	case deletionPolicyAbandon:
+		tflog.Info(ctx, "Abandoning project, leaving it in Space", map[string]any{"id": state.ID.ValueString()})
+	case deletionPolicyArchive:
+		if state.Archived.ValueBool() {
+			return
+		}
+		err := r.client.ArchiveProject(ctx, state.ID.ValueString())
+		if err != nil && !space.IsNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Error Archiving Space project",
+				err.Error(),
+			)
+			return
+		}
+	default:
+		err := r.client.DeleteProject(ctx, state.ID.ValueString())
+		if err != nil && !space.IsNotFound(err) {
 			resp.Diagnostics.AddError(
 				"Error Deleting Space project",
 				err.Error(),
@@ -329,9 +453,42 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+// ImportState accepts a project ID, key:KEY, or a bare key. A bare value that
+// looks like a key is tried as one first and falls back to being an ID.
 func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute.
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	project, err := lookupProject(ctx, r.client, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing Space project "+req.ID,
+			err.Error(),
+		)
+		return
+	}
+
+	// Memberships and the remaining attributes are filled in by Read.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), project.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), project.Key.Key)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), project.Name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protected"), false)...)
+}
+
+// lookupProject resolves an import identifier to a project: an ID, key:KEY,
+// or a bare key.
+func lookupProject(ctx context.Context, client *space.Client, identifier string) (space.Project, error) {
+	if strings.HasPrefix(identifier, "key:") {
+		return client.GetProjectByKey(ctx, strings.TrimPrefix(identifier, "key:"))
+	}
+	if strings.HasPrefix(identifier, "id:") {
+		return client.GetProject(ctx, strings.TrimPrefix(identifier, "id:"))
+	}
+
+	if projectKeyPattern.MatchString(identifier) {
+		project, err := client.GetProjectByKey(ctx, identifier)
+		if !space.IsNotFound(err) {
+			return project, err
+		}
+	}
+	return client.GetProject(ctx, identifier)
 }
 
 func (r *projectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -355,12 +512,21 @@ func (r *projectResource) Configure(_ context.Context, req resource.ConfigureReq
 
 func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Required: true,
 			},
 			"key": schema.StringAttribute{
-				Computed: true,
+				Optional:    true,
+				Computed:    true,
+				Description: "Project key. Derived from the name when not set; changing it renames the key in place.",
+				Validators: []validator.String{
+					projectKeyValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -371,202 +537,454 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the project was created, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"latest_repository_activity": schema.StringAttribute{
+				Computed:    true,
+				Description: "When any repository of the project last changed, in RFC 3339 format. Null if the project has no repository activity.",
+			},
 			"protected": schema.BoolAttribute{
 				Optional: true,
 				Computed: true,
 				Default:  booldefault.StaticBool(false),
 			},
-			"member_teams": schema.ListAttribute{
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Description of the project.",
+				Default:     stringdefault.StaticString(""),
+			},
+			"private": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the project is visible only to its members.",
+				Default:     booldefault.StaticBool(false),
+			},
+			"archived": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the project is archived. Archiving keeps its data but makes it read-only.",
+				Default:     booldefault.StaticBool(false),
+			},
+			"deletion_policy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "What destroying the resource does to the project: `delete` it, `archive` it, or `abandon` it in Space untouched. Defaults to `delete`.",
+				Default:     stringdefault.StaticString(deletionPolicyDelete),
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{deletionPolicyDelete, deletionPolicyArchive, deletionPolicyAbandon}},
+				},
+			},
+			"membership_mode": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "How the membership lists are enforced. `authoritative` removes anyone not listed, including people and teams added outside Terraform, but keeps the administrator grant Space gives the profile that created the project unless `admins` lists it; `additive` only manages the listed principals. Defaults to `authoritative`.",
+				Default:     stringdefault.StaticString(membershipModeAuthoritative),
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{membershipModeAuthoritative, membershipModeAdditive}},
+				},
+			},
+			"icon": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a local image file uploaded as the project icon.",
+			},
+			"member_teams": schema.SetAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 			},
-			"members": schema.ListAttribute{
+			"members": schema.SetAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 			},
-			"admin_teams": schema.ListAttribute{
+			"admin_teams": schema.SetAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 			},
-			"admins": schema.ListAttribute{
+			"admins": schema.SetAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 			},
+			"roles": schema.ListNestedAttribute{
+				Optional: true,
+				Description: "Grants of custom project roles, one entry per role. Use `members`, `member_teams`, `admins` and `admin_teams` for the built-in roles. " +
+					"A `jetbrainsspace_project_role` of this project can't be granted here, since the role depends on the project; grant it with `jetbrainsspace_project_member` or `jetbrainsspace_project_team_member` instead.",
+				Validators: []validator.List{
+					projectRolesValidator{},
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Required:    true,
+							Description: "Key of a custom role that already exists in the project, such as one created outside Terraform.",
+						},
+						"members": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Usernames of the profiles granted the role.",
+						},
+						"teams": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Names of the teams granted the role.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
-func (r *projectResource) UpdateProjectRoles(ctx context.Context, plan projectResourceModel, projectID string, toRemove []string, isTeam bool, memberType string) error {
-
-	// Prepare request to map team to project role (Members).
-	var members []interface{}
-
-	members = append(members, memberType)
-	var empty []interface{}
-	empty = append(empty, "")
-	if isTeam {
-		data := space.ProjectRoles{
-			AddRoles:    members,
-			RemoveRoles: empty,
-		}
-		if memberType == "member" {
-			for _, v := range plan.MemberTeams {
-				data.Team = "name:" + v.ValueString()
-				err := r.client.MapTeamToProjectRole(data, projectID)
-				if err != nil {
-					return err
+// UpgradeState migrates state written before the membership attributes
+// became sets.
+func (r *projectResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &projectSchemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior projectResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
 				}
-			}
-		} else {
-			for _, v := range plan.AdminTeams {
 
-				data.Team = "name:" + v.ValueString()
-				err := r.client.MapTeamToProjectRole(data, projectID)
-				if err != nil {
-					return err
+				// Lists and sets decode into the same slices, so the
+				// memberships carry over once duplicates, which a list
+				// allowed, are dropped. Attributes added since version 0
+				// take their defaults; the timestamps stay null until the
+				// next Read.
+				state := projectResourceModel{
+					Name:           prior.Name,
+					Key:            prior.Key,
+					ID:             prior.ID,
+					LastUpdated:    prior.LastUpdated,
+					Protected:      prior.Protected,
+					Description:    types.StringValue(""),
+					Private:        types.BoolValue(false),
+					Icon:           types.StringNull(),
+					Archived:       types.BoolValue(false),
+					DeletionPolicy: types.StringValue(deletionPolicyDelete),
+					MembershipMode: types.StringValue(membershipModeAuthoritative),
+					CreatedAt:      types.StringNull(),
+					LatestActivity: types.StringNull(),
+					MemberTeams:    uniquePrincipals(prior.MemberTeams),
+					Members:        uniquePrincipals(prior.Members),
+					AdminTeams:     uniquePrincipals(prior.AdminTeams),
+					Admins:         uniquePrincipals(prior.Admins),
 				}
-			}
-		}
+				resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			},
+		},
+	}
+}
 
-	} else {
-		data := space.ProjectMembers{
-			AddRoles:    members,
-			RemoveRoles: empty,
+// uniquePrincipals drops repeated principals, keeping the first occurrence and
+// an explicitly empty list.
+func uniquePrincipals(values []types.String) []types.String {
+	if values == nil {
+		return nil
+	}
+	out := []types.String{}
+	seen := map[string]bool{}
+	for _, v := range values {
+		if !seen[v.ValueString()] {
+			seen[v.ValueString()] = true
+			out = append(out, v)
 		}
-		if memberType == "member" {
+	}
+	return out
+}
 
-			for _, v := range plan.Members {
-				data.Profile = "username:" + v.ValueString()
-				err := r.client.SetProjectMembers(data, projectID)
-				if err != nil {
-					return err
-				}
-			}
+// projectSchemaV0 is the project schema as released at version 0, with the
+// membership attributes as lists. It must never change: it decodes state
+// written by that version.
+var projectSchemaV0 = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Required: true,
+		},
+		"key": schema.StringAttribute{
+			Computed: true,
+		},
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"last_updated": schema.StringAttribute{
+			Computed: true,
+		},
+		"protected": schema.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(false),
+		},
+		"member_teams": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+		"members": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+		"admin_teams": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+		"admins": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+	},
+}
+
+// SetProjectIcon uploads the image at iconPath and makes it the project's
+// icon. An empty path removes the icon.
+func (r *projectResource) SetProjectIcon(ctx context.Context, projectID string, iconPath string) error {
+	if iconPath == "" {
+		return r.client.SetProjectIcon(ctx, projectID, "")
+	}
+
+	content, err := os.ReadFile(iconPath)
+	if err != nil {
+		return fmt.Errorf("could not read icon: %w", err)
+	}
+	mediaType := mime.TypeByExtension(filepath.Ext(iconPath))
+	if mediaType == "" {
+		mediaType = http.DetectContentType(content)
+	}
+
+	iconID, err := r.client.UploadFile(ctx, filepath.Base(iconPath), mediaType, content)
+	if err != nil {
+		return err
+	}
+
+	return r.client.SetProjectIcon(ctx, projectID, iconID)
+}
+
+// AddProjectMembers grants the role with the given key in the project to
+// each named profile or team.
+func (r *projectResource) AddProjectMembers(ctx context.Context, projectID string, toAdd []string, isTeam bool, role string) error {
+	roles := []string{role}
+
+	for _, v := range toAdd {
+		var err error
+		if isTeam {
+			err = r.client.MapTeamToProjectRole(ctx, space.ProjectRoles{
+				Team:     "name:" + v,
+				AddRoles: roles,
+			}, projectID)
 		} else {
-			if memberType == "admin" {
-				for _, v := range plan.Admins {
-					data.Profile = "username:" + v.ValueString()
-					err := r.client.SetProjectMembers(data, projectID)
-					if err != nil {
-						return err
-					}
-				}
-			}
+			err = r.client.SetProjectMembers(ctx, space.ProjectMembers{
+				Profile:  "username:" + v,
+				AddRoles: roles,
+			}, projectID)
+		}
+		if err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-func (r *projectResource) RemoveProjectMembers(ctx context.Context, plan projectResourceModel, projectID string, toRemove []string, isTeam bool, memberType string) error {
-
-	// Prepare request to map team to project role (Members).
-	var members []interface{}
+// RemoveProjectMembers revokes the role with the given key in the project
+// from each named profile or team.
+func (r *projectResource) RemoveProjectMembers(ctx context.Context, projectID string, toRemove []string, isTeam bool, role string) error {
+	roles := []string{role}
 
-	members = append(members, memberType)
-	var empty []interface{}
-	empty = append(empty, "")
-
-	if len(toRemove) > 0 {
-		var data space.ProjectRoles
+	for _, v := range toRemove {
+		var err error
 		if isTeam {
-			data = space.ProjectRoles{
-				AddRoles:    empty,
-				RemoveRoles: members,
-			}
+			err = r.client.MapTeamToProjectRole(ctx, space.ProjectRoles{
+				Team:        "name:" + v,
+				RemoveRoles: roles,
+			}, projectID)
+		} else {
+			err = r.client.SetProjectMembers(ctx, space.ProjectMembers{
+				Profile:     "username:" + v,
+				RemoveRoles: roles,
+			}, projectID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-			for _, v := range toRemove {
+// applyMembership revokes the role from principals only in prior and grants
+// it to those only in planned.
+func (r *projectResource) applyMembership(ctx context.Context, projectID string, m projectMembership, prior, planned []types.String) error {
+	toAdd, toRemove := diffPrincipals(prior, planned)
+	err := r.RemoveProjectMembers(ctx, projectID, toRemove, m.isTeam, m.role)
+	if err != nil {
+		return err
+	}
+	return r.AddProjectMembers(ctx, projectID, toAdd, m.isTeam, m.role)
+}
 
-				data.Team = "name:" + v
+// projectMembership ties a membership attribute to the principal kind and
+// role it manages.
+type projectMembership struct {
+	path   path.Path
+	value  *[]types.String
+	isTeam bool
+	role   string
+}
 
-				err := r.client.MapTeamToProjectRole(data, projectID)
-				if err != nil {
-					return err
-				}
-			}
-		} else {
-			data := space.ProjectMembers{
-				AddRoles:    empty,
-				RemoveRoles: members,
-			}
-			for _, v := range toRemove {
+// key identifies the grants a membership manages.
+func (m projectMembership) key() string {
+	return fmt.Sprintf("%s/%t", m.role, m.isTeam)
+}
 
-				data.Profile = "username:" + v
+// projectMemberships lists the four membership attributes of a project, always
+// in the same order, followed by the profiles and teams of each roles entry.
+func projectMemberships(m *projectResourceModel) []projectMembership {
+	memberships := []projectMembership{
+		{path.Root("members"), &m.Members, false, "member"},
+		{path.Root("member_teams"), &m.MemberTeams, true, "member"},
+		{path.Root("admins"), &m.Admins, false, "admin"},
+		{path.Root("admin_teams"), &m.AdminTeams, true, "admin"},
+	}
+	for i := range m.Roles {
+		entry := path.Root("roles").AtListIndex(i)
+		role := m.Roles[i].Role.ValueString()
+		memberships = append(memberships,
+			projectMembership{entry.AtName("members"), &m.Roles[i].Members, false, role},
+			projectMembership{entry.AtName("teams"), &m.Roles[i].Teams, true, role},
+		)
+	}
+	return memberships
+}
 
-				err := r.client.SetProjectMembers(data, projectID)
-				if err != nil {
-					return err
-				}
-			}
+// containsPrincipal reports whether name is in a membership list.
+func containsPrincipal(values []types.String, name string) bool {
+	for _, v := range values {
+		if v.ValueString() == name {
+			return true
 		}
 	}
-
-	return nil
+	return false
 }
 
-// CompareProjectRoles - Compare roles in state and plan.
-func CompareProjectRoles(ctx context.Context, path path.Path, state tfsdk.State, plan tfsdk.Plan) (bool, []string, error) {
-	var stateVal []types.String
-	var planVal []types.String
-	var different = false
-
-	diag := state.GetAttribute(ctx, path, &stateVal)
-	if diag.HasError() {
-		return false, []string{""}, fmt.Errorf("Problem getting state value for roles")
-	}
-	diag = plan.GetAttribute(ctx, path, &planVal)
-	if diag.HasError() {
-		return false, []string{""}, fmt.Errorf("Problem getting plan value for roles")
-	}
-	if len(stateVal) != len(planVal) {
-		different = true
-	} else {
-		for i, v := range stateVal {
-			if v != planVal[i] {
-				different = true
-			}
+// diffPrincipals compares two membership lists as sets, so reordering a list
+// never results in API calls.
+func diffPrincipals(prior, planned []types.String) (toAdd, toRemove []string) {
+	priorSet := map[string]bool{}
+	for _, v := range prior {
+		priorSet[v.ValueString()] = true
+	}
+	plannedSet := map[string]bool{}
+	for _, v := range planned {
+		plannedSet[v.ValueString()] = true
+		if !priorSet[v.ValueString()] {
+			toAdd = append(toAdd, v.ValueString())
 		}
 	}
-	if different {
-		var toRemove []string
-		for _, v := range stateVal {
+	for _, v := range prior {
+		if !plannedSet[v.ValueString()] {
 			toRemove = append(toRemove, v.ValueString())
 		}
-		return true, toRemove, nil
 	}
-	var nothing []string
-	return true, nothing, nil
+	return toAdd, toRemove
+}
+
+// reconcilePrincipals merges the principals Space reports into a membership
+// list from state. Known principals keep their position so ordering never
+// causes a diff. In authoritative mode anyone added outside Terraform is
+// appended, so the next plan removes them; in additive mode they're ignored.
+func reconcilePrincipals(managed []types.String, actual []string, authoritative bool) []types.String {
+	present := map[string]bool{}
+	for _, v := range actual {
+		present[v] = true
+	}
+
+	var out []types.String
+	seen := map[string]bool{}
+	for _, v := range managed {
+		if present[v.ValueString()] {
+			out = append(out, v)
+			seen[v.ValueString()] = true
+		}
+	}
+	if authoritative {
+		for _, v := range actual {
+			if !seen[v] {
+				out = append(out, types.StringValue(v))
+			}
+		}
+	}
 
+	// Keep an explicitly empty list from turning into null.
+	if out == nil && managed != nil {
+		out = []types.String{}
+	}
+	return out
+}
+
+// dateTimeValue formats a Space timestamp as RFC 3339. A missing timestamp is
+// null.
+func dateTimeValue(t *space.DateTime) types.String {
+	if t == nil || t.Timestamp == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(t.Time().Format(time.RFC3339))
 }
 
 // FetchUpdatedAccessForProject - obtain the latest access settings for the project.
-func FetchUpdatedAccessForProject(state projectResourceModel, project space.Project) (projectResourceModel, error) {
+// people is only consulted for the roles attribute. creator, when set, is left
+// out of the admins unless state already lists it.
+func FetchUpdatedAccessForProject(state projectResourceModel, project space.Project, people space.ProjectPeople, creator string) (projectResourceModel, error) {
+	authoritative := state.MembershipMode.ValueString() != membershipModeAdditive
 
-	var memberTeamsState []types.String
-	for _, value := range project.MemberTeams {
-		memberTeamsState = append(memberTeamsState, types.StringValue(value.Name))
+	memberTeams, members, adminTeams, admins := projectAccess(project)
+	if creator != "" && !containsPrincipal(state.Admins, creator) {
+		admins = withoutPrincipal(admins, creator)
 	}
 
-	state.MemberTeams = memberTeamsState
+	state.MemberTeams = reconcilePrincipals(state.MemberTeams, memberTeams, authoritative)
+	state.Members = reconcilePrincipals(state.Members, members, authoritative)
+	state.AdminTeams = reconcilePrincipals(state.AdminTeams, adminTeams, authoritative)
+	state.Admins = reconcilePrincipals(state.Admins, admins, authoritative)
+	for i, entry := range state.Roles {
+		profiles, teams := people.WithRole(entry.Role.ValueString())
+		state.Roles[i].Members = reconcilePrincipals(entry.Members, profiles, authoritative)
+		state.Roles[i].Teams = reconcilePrincipals(entry.Teams, teams, authoritative)
+	}
+
+	return state, nil
 
-	var memberState []types.String
+}
+
+// projectAccess lists who holds the built-in roles of a project.
+func projectAccess(project space.Project) (memberTeams, members, adminTeams, admins []string) {
+	for _, value := range project.MemberTeams {
+		memberTeams = append(memberTeams, value.Name)
+	}
 	for _, value := range project.Members {
-		memberState = append(memberState, types.StringValue(value.Profile.Username))
+		members = append(members, value.Profile.Username)
 	}
-	state.Members = memberState
-
-	var adminTeamsState []types.String
 	for _, value := range project.AdminTeams {
-		adminTeamsState = append(adminTeamsState, types.StringValue(value.Name))
+		adminTeams = append(adminTeams, value.Name)
 	}
-
-	state.AdminTeams = adminTeamsState
-
-	var adminState []types.String
 	for _, value := range project.Admins {
-		adminState = append(adminState, types.StringValue(value.Username))
+		admins = append(admins, value.Username)
 	}
-	state.Admins = adminState
-
-	return state, nil
+	return memberTeams, members, adminTeams, admins
+}
 
+// withoutPrincipal returns values without name.
+func withoutPrincipal(values []string, name string) []string {
+	var out []string
+	for _, v := range values {
+		if v != name {
+			out = append(out, v)
+		}
+	}
+	return out
 }
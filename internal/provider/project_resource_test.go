@@ -0,0 +1,767 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"terraform-provider-jetbrains-space/internal/spacetest"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccProjectResource(t *testing.T) {
+	s := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectsDestroyed(s),
+		Steps: []resource.TestStep{
+			// Create and Read testing.
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name         = "Web App"
+  description  = "Storefront"
+  members      = ["alice", "bob"]
+  admin_teams  = ["Platform"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "name", "Web App"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "key", "WEB-APP"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "description", "Storefront"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "archived", "false"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "deletion_policy", "delete"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "members.#", "2"),
+					resource.TestCheckTypeSetElemAttr("jetbrainsspace_project.test", "members.*", "alice"),
+					resource.TestCheckTypeSetElemAttr("jetbrainsspace_project.test", "admin_teams.*", "Platform"),
+					resource.TestCheckResourceAttrSet("jetbrainsspace_project.test", "id"),
+					resource.TestCheckResourceAttrSet("jetbrainsspace_project.test", "created_at"),
+					testAccCheckProjectMember(s, "jetbrainsspace_project.test", "bob", "member"),
+				),
+			},
+			// ImportState testing, by ID and by key.
+			{
+				ResourceName:            "jetbrainsspace_project.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				ResourceName:            "jetbrainsspace_project.test",
+				ImportState:             true,
+				ImportStateId:           "WEB-APP",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing: reordering members is a no-op, the rest
+			// changes in place.
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name         = "Web Shop"
+  key          = "SHOP"
+  description  = "Storefront and checkout"
+  members      = ["bob", "carol"]
+  admin_teams  = ["Platform"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "name", "Web Shop"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "key", "SHOP"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "description", "Storefront and checkout"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "members.#", "2"),
+					resource.TestCheckTypeSetElemAttr("jetbrainsspace_project.test", "members.*", "carol"),
+					testAccCheckProjectMember(s, "jetbrainsspace_project.test", "carol", "member"),
+					testAccCheckProjectMember(s, "jetbrainsspace_project.test", "alice", ""),
+				),
+			},
+			// Delete testing automatically occurs in TestCase.
+		},
+	})
+}
+
+func TestAccProjectResource_removedOutsideTerraform(t *testing.T) {
+	s := newTestServer(t)
+	config := s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name = "Ops"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: func(state *terraform.State) error {
+					s.DeleteProject(state.RootModule().Resources["jetbrainsspace_project.test"].Primary.ID)
+					return nil
+				},
+				ExpectNonEmptyPlan: true,
+			},
+			// The next refresh drops the project, so it is created again.
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("jetbrainsspace_project.test", "key", "OPS"),
+			},
+		},
+	})
+}
+
+func TestAccProjectResource_noDerivableKey(t *testing.T) {
+	s := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name = "***"
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing Project Key`),
+			},
+		},
+	})
+}
+
+func TestAccProjectResource_archived(t *testing.T) {
+	s := newTestServer(t)
+	patches := 0
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectsDestroyed(s),
+		Steps: []resource.TestStep{
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name            = "Legacy"
+  members         = ["alice"]
+  archived        = true
+  deletion_policy = "archive"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "archived", "true"),
+					testAccCheckProjectArchived(s, "jetbrainsspace_project.test", true),
+					func(*terraform.State) error {
+						patches = countProjectPatches(s)
+						return nil
+					},
+				),
+			},
+			// Any change to an archived project is applied between unarchiving
+			// and archiving it again.
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name            = "Legacy"
+  members         = ["alice"]
+  archived        = true
+  deletion_policy = "delete"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "deletion_policy", "delete"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "archived", "true"),
+					testAccCheckProjectArchived(s, "jetbrainsspace_project.test", true),
+					func(*terraform.State) error {
+						if n := countProjectPatches(s); n != patches+1 {
+							return fmt.Errorf("sent %d project updates, want 1", n-patches)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name            = "Legacy"
+  description     = "Read only"
+  members         = ["alice", "bob"]
+  archived        = true
+  deletion_policy = "delete"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "description", "Read only"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "archived", "true"),
+					testAccCheckProjectArchived(s, "jetbrainsspace_project.test", true),
+					testAccCheckProjectMember(s, "jetbrainsspace_project.test", "bob", "member"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectResource_icon(t *testing.T) {
+	s := newTestServer(t)
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.png")
+	second := filepath.Join(dir, "second.png")
+	if err := os.WriteFile(first, []byte("\x89PNG\r\n\x1a\nfirst"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(second, []byte("\x89PNG\r\n\x1a\nsecond"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config := func(icon string) string {
+		if icon == "" {
+			return s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name = "Brand"
+}
+`
+		}
+		return s.ProviderConfig() + fmt.Sprintf(`
+resource "jetbrainsspace_project" "test" {
+  name = "Brand"
+  icon = %q
+}
+`, icon)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectsDestroyed(s),
+		Steps: []resource.TestStep{
+			{
+				Config: config(first),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "icon", first),
+					testAccCheckProjectIcon(s, "jetbrainsspace_project.test", "first"),
+				),
+			},
+			// Pointing at another file uploads it in place.
+			{
+				Config: config(second),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "icon", second),
+					testAccCheckProjectIcon(s, "jetbrainsspace_project.test", "second"),
+				),
+			},
+			// Removing the attribute removes the icon.
+			{
+				Config: config(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("jetbrainsspace_project.test", "icon"),
+					testAccCheckProjectIcon(s, "jetbrainsspace_project.test", ""),
+				),
+			},
+		},
+	})
+}
+
+// Space makes the creator an administrator. Authoritative mode must leave
+// that grant alone unless the configuration manages it.
+func TestAccProjectResource_creatorStaysAdmin(t *testing.T) {
+	s := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectsDestroyed(s),
+		Steps: []resource.TestStep{
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name   = "Tools"
+  admins = ["alice"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "admins.#", "1"),
+					testAccCheckProjectMember(s, "jetbrainsspace_project.test", spacetest.Username, "admin"),
+				),
+			},
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name   = "Tools"
+  admins = ["bob"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckProjectMember(s, "jetbrainsspace_project.test", "alice", ""),
+					testAccCheckProjectMember(s, "jetbrainsspace_project.test", spacetest.Username, "admin"),
+				),
+			},
+			// Once listed, the creator is managed like anyone else.
+			{
+				Config: s.ProviderConfig() + fmt.Sprintf(`
+resource "jetbrainsspace_project" "test" {
+  name   = "Tools"
+  admins = ["bob", %q]
+}
+`, spacetest.Username),
+				Check: resource.TestCheckResourceAttr("jetbrainsspace_project.test", "admins.#", "2"),
+			},
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name   = "Tools"
+  admins = ["bob"]
+}
+`,
+				Check: testAccCheckProjectMember(s, "jetbrainsspace_project.test", spacetest.Username, ""),
+			},
+		},
+	})
+}
+
+func TestAccProjectResource_roles(t *testing.T) {
+	s := newTestServer(t)
+	var projectID string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectsDestroyed(s),
+		Steps: []resource.TestStep{
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name = "Docs"
+}
+`,
+				Check: func(state *terraform.State) error {
+					projectID = state.RootModule().Resources["jetbrainsspace_project.test"].Primary.ID
+					return nil
+				},
+			},
+			// Grant a role created outside Terraform.
+			{
+				PreConfig: func() {
+					s.AddRole(projectID, "reviewer")
+				},
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name = "Docs"
+  roles = [{
+    role    = "reviewer"
+    members = ["alice"]
+    teams   = ["Backend"]
+  }]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "roles.#", "1"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project.test", "roles.0.role", "reviewer"),
+					func(state *terraform.State) error {
+						return testAccCheckPrincipalRoles(s, projectID, false, "alice", "reviewer")(state)
+					},
+					func(state *terraform.State) error {
+						return testAccCheckPrincipalRoles(s, projectID, true, "Backend", "reviewer")(state)
+					},
+				),
+			},
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name = "Docs"
+  roles = [{
+    role    = "reviewer"
+    members = ["bob"]
+  }]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					func(state *terraform.State) error {
+						return testAccCheckPrincipalRoles(s, projectID, false, "alice")(state)
+					},
+					func(state *terraform.State) error {
+						return testAccCheckPrincipalRoles(s, projectID, false, "bob", "reviewer")(state)
+					},
+					func(state *terraform.State) error {
+						return testAccCheckPrincipalRoles(s, projectID, true, "Backend")(state)
+					},
+				),
+			},
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name = "Docs"
+}
+`,
+				Check: func(state *terraform.State) error {
+					return testAccCheckPrincipalRoles(s, projectID, false, "bob")(state)
+				},
+			},
+		},
+	})
+}
+
+func TestAccProjectResource_invalidRoles(t *testing.T) {
+	s := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name = "Docs"
+  roles = [{
+    role    = "admin"
+    members = ["alice"]
+  }]
+}
+`,
+				ExpectError: regexp.MustCompile(`Built-in Project Role`),
+			},
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project" "test" {
+  name = "Docs"
+  roles = [
+    {
+      role    = "reviewer"
+      members = ["alice"]
+    },
+    {
+      role  = "reviewer"
+      teams = ["Backend"]
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`Duplicate Project Role`),
+			},
+		},
+	})
+}
+
+// testAccCheckProjectsDestroyed verifies that destroying the configuration
+// left no projects behind.
+func testAccCheckProjectsDestroyed(s *spacetest.Server) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != "jetbrainsspace_project" {
+				continue
+			}
+			if s.Project(rs.Primary.ID) != nil {
+				return fmt.Errorf("project %s still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+// testAccCheckProjectMember verifies that username holds role in the project,
+// or holds no role at all when role is empty.
+func testAccCheckProjectMember(s *spacetest.Server, name, username, role string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		project := s.Project(rs.Primary.ID)
+		if project == nil {
+			return fmt.Errorf("project %s not found in Space", rs.Primary.ID)
+		}
+		roles := project.Members[username]
+		if role == "" {
+			if len(roles) > 0 {
+				return fmt.Errorf("%s still holds roles %v", username, roles)
+			}
+			return nil
+		}
+		if !roles[role] {
+			return fmt.Errorf("%s does not hold role %s, has %v", username, role, roles)
+		}
+		return nil
+	}
+}
+
+// testAccCheckProjectArchived verifies the archived flag of the project in
+// Space.
+func testAccCheckProjectArchived(s *spacetest.Server, name string, archived bool) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		project := s.Project(rs.Primary.ID)
+		if project == nil {
+			return fmt.Errorf("project %s not found in Space", rs.Primary.ID)
+		}
+		if project.Archived != archived {
+			return fmt.Errorf("project %s archived = %t, want %t", rs.Primary.ID, project.Archived, archived)
+		}
+		return nil
+	}
+}
+
+// testAccCheckProjectIcon verifies that the project's icon in Space ends with
+// suffix, or that it has no icon when suffix is empty.
+func testAccCheckProjectIcon(s *spacetest.Server, name, suffix string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		project := s.Project(rs.Primary.ID)
+		if project == nil {
+			return fmt.Errorf("project %s not found in Space", rs.Primary.ID)
+		}
+		if suffix == "" {
+			if project.Icon != "" {
+				return fmt.Errorf("project %s still has icon %s", rs.Primary.ID, project.Icon)
+			}
+			return nil
+		}
+		if content := string(s.Attachment(project.Icon)); !strings.HasSuffix(content, suffix) {
+			return fmt.Errorf("project icon is %q, want the %s image", content, suffix)
+		}
+		return nil
+	}
+}
+
+// countProjectPatches counts the project updates the server has received.
+func countProjectPatches(s *spacetest.Server) int {
+	n := 0
+	for _, r := range s.Requests() {
+		if r.Method == "PATCH" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDiffPrincipals(t *testing.T) {
+	cases := []struct {
+		name       string
+		prior      []types.String
+		planned    []types.String
+		wantAdd    []string
+		wantRemove []string
+	}{
+		{
+			name:    "reordered",
+			prior:   stringList("alice", "bob", "carol"),
+			planned: stringList("carol", "alice", "bob"),
+		},
+		{
+			name:    "unchanged",
+			prior:   stringList("alice"),
+			planned: stringList("alice"),
+		},
+		{
+			name:    "from null",
+			planned: stringList("alice", "bob"),
+			wantAdd: []string{"alice", "bob"},
+		},
+		{
+			name:       "to empty",
+			prior:      stringList("alice", "bob"),
+			planned:    []types.String{},
+			wantRemove: []string{"alice", "bob"},
+		},
+		{
+			name:       "swap one",
+			prior:      stringList("alice", "bob"),
+			planned:    stringList("bob", "carol"),
+			wantAdd:    []string{"carol"},
+			wantRemove: []string{"alice"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			toAdd, toRemove := diffPrincipals(tc.prior, tc.planned)
+			sort.Strings(toAdd)
+			sort.Strings(toRemove)
+			if !reflect.DeepEqual(toAdd, tc.wantAdd) {
+				t.Errorf("toAdd = %v, want %v", toAdd, tc.wantAdd)
+			}
+			if !reflect.DeepEqual(toRemove, tc.wantRemove) {
+				t.Errorf("toRemove = %v, want %v", toRemove, tc.wantRemove)
+			}
+		})
+	}
+}
+
+func TestReconcilePrincipals(t *testing.T) {
+	cases := []struct {
+		name          string
+		managed       []types.String
+		actual        []string
+		authoritative bool
+		want          []types.String
+	}{
+		{
+			name:          "reordered by Space",
+			managed:       stringList("alice", "bob"),
+			actual:        []string{"bob", "alice"},
+			authoritative: true,
+			want:          stringList("alice", "bob"),
+		},
+		{
+			name:          "added outside Terraform, authoritative",
+			managed:       stringList("alice"),
+			actual:        []string{"mallory", "alice"},
+			authoritative: true,
+			want:          stringList("alice", "mallory"),
+		},
+		{
+			name:    "added outside Terraform, additive",
+			managed: stringList("alice"),
+			actual:  []string{"mallory", "alice"},
+			want:    stringList("alice"),
+		},
+		{
+			name:          "removed outside Terraform",
+			managed:       stringList("alice", "bob"),
+			actual:        []string{"bob"},
+			authoritative: true,
+			want:          stringList("bob"),
+		},
+		{
+			name:          "explicitly empty stays empty",
+			managed:       []types.String{},
+			authoritative: true,
+			want:          []types.String{},
+		},
+		{
+			name:    "emptied outside Terraform stays empty",
+			managed: stringList("alice"),
+			want:    []types.String{},
+		},
+		{
+			name:    "null stays null",
+			managed: nil,
+			actual:  []string{"mallory"},
+			want:    nil,
+		},
+		{
+			name:          "null picks up additions when authoritative",
+			managed:       nil,
+			actual:        []string{"mallory"},
+			authoritative: true,
+			want:          stringList("mallory"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := reconcilePrincipals(tc.managed, tc.actual, tc.authoritative)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("reconcilePrincipals = %v, want %v", got, tc.want)
+			}
+			if (got == nil) != (tc.want == nil) {
+				t.Errorf("reconcilePrincipals returned nil = %t, want %t", got == nil, tc.want == nil)
+			}
+		})
+	}
+}
+
+func stringList(values ...string) []types.String {
+	list := make([]types.String, 0, len(values))
+	for _, v := range values {
+		list = append(list, types.StringValue(v))
+	}
+	return list
+}
+
+func TestProjectResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	server, err := testAccProtoV6ProviderFactories["jetbrainsspace"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Terraform always fetches the schemas first, which registers the
+	// resource types.
+	if _, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// State as written by schema version 0, with membership lists.
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "jetbrainsspace_project",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: []byte(`{
+  "id": "p1",
+  "name": "App",
+  "key": "APP",
+  "last_updated": "Monday, 02-Jan-23 15:04:05 UTC",
+  "protected": false,
+  "member_teams": null,
+  "members": ["bob", "alice", "bob"],
+  "admin_teams": [],
+  "admins": ["alice"]
+}`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("%s: %s", d.Summary, d.Detail)
+	}
+	if t.Failed() {
+		return
+	}
+
+	var schemaResp fwresource.SchemaResponse
+	(&projectResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+	upgraded, err := resp.UpgradedState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: upgraded}
+
+	var model projectResourceModel
+	if diags := state.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("decoding upgraded state: %v", diags)
+	}
+	if model.ID.ValueString() != "p1" || model.Key.ValueString() != "APP" || model.Name.ValueString() != "App" {
+		t.Errorf("upgraded state = %+v", model)
+	}
+	if model.Description.ValueString() != "" || model.Private.ValueBool() || model.Archived.ValueBool() || !model.Icon.IsNull() {
+		t.Errorf("attributes added after version 0 should take their defaults, got %+v", model)
+	}
+	if model.DeletionPolicy.ValueString() != "delete" || model.MembershipMode.ValueString() != "authoritative" {
+		t.Errorf("deletion_policy = %v and membership_mode = %v, want the defaults", model.DeletionPolicy, model.MembershipMode)
+	}
+	if !model.CreatedAt.IsNull() || !model.LatestActivity.IsNull() {
+		t.Errorf("timestamps should be null until the next Read, got %v and %v", model.CreatedAt, model.LatestActivity)
+	}
+	if got := valueStrings(model.Members); !reflect.DeepEqual(got, []string{"alice", "bob"}) {
+		t.Errorf("members = %v, want [alice bob]", got)
+	}
+	if got := valueStrings(model.Admins); !reflect.DeepEqual(got, []string{"alice"}) {
+		t.Errorf("admins = %v, want [alice]", got)
+	}
+	if model.MemberTeams != nil {
+		t.Errorf("member_teams = %v, want null", model.MemberTeams)
+	}
+	if model.AdminTeams == nil || len(model.AdminTeams) != 0 {
+		t.Errorf("admin_teams = %v, want empty", model.AdminTeams)
+	}
+	if model.Roles != nil {
+		t.Errorf("roles = %+v, want null", model.Roles)
+	}
+}
+
+// valueStrings returns the values of a membership list, sorted.
+func valueStrings(values []types.String) []string {
+	var out []string
+	for _, v := range values {
+		out = append(out, v.ValueString())
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	space "terraform-provider-jetbrains-space/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &projectRoleResource{}
+	_ resource.ResourceWithConfigure   = &projectRoleResource{}
+	_ resource.ResourceWithImportState = &projectRoleResource{}
+)
+
+// NewProjectRoleResource is a helper function to simplify the provider implementation.
+func NewProjectRoleResource() resource.Resource {
+	return &projectRoleResource{}
+}
+
+// projectRoleResource manages a custom role in a project.
+type projectRoleResource struct {
+	client *space.Client
+}
+
+// Metadata returns the resource type name.
+func (r *projectRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_role"
+}
+
+func (r *projectRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A custom project role with its own set of permissions.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the role.",
+			},
+			"key": schema.StringAttribute{
+				Computed:    true,
+				Description: "Key to grant the role with, in `roles` of project memberships.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"permissions": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Permissions granted by the role.",
+			},
+		},
+	}
+}
+
+// Create a new resource.
+func (r *projectRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan projectRoleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.client.CreateProjectRole(ctx, plan.ProjectID.ValueString(), space.CreateProjectRoleData{
+		Name:        plan.Name.ValueString(),
+		Permissions: stringValues(plan.Permissions),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating project role "+plan.Name.String(),
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(role.ID)
+	plan.Key = types.StringValue(role.Key)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *projectRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state projectRoleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.client.GetProjectRole(ctx, state.ProjectID.ValueString(), state.ID.ValueString())
+	if space.IsNotFound(err) {
+		tflog.Warn(ctx, "Project role not found, removing from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Jetbrains Space project role",
+			"Could not read role ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(role.Name)
+	state.Key = types.StringValue(role.Key)
+	state.Permissions = reconcilePrincipals(state.Permissions, role.Permissions, true)
+	if state.Permissions == nil {
+		state.Permissions = []types.String{}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *projectRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan projectRoleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.client.UpdateProjectRole(ctx, plan.ProjectID.ValueString(), plan.ID.ValueString(), space.CreateProjectRoleData{
+		Name:        plan.Name.ValueString(),
+		Permissions: stringValues(plan.Permissions),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating project role "+plan.ID.ValueString(),
+			err.Error(),
+		)
+		return
+	}
+
+	plan.Key = types.StringValue(role.Key)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *projectRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state projectRoleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteProjectRole(ctx, state.ProjectID.ValueString(), state.ID.ValueString())
+	if err != nil && !space.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Space project role",
+			err.Error(),
+		)
+	}
+}
+
+func (r *projectRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, roleID, ok := strings.Cut(req.ID, ",")
+	if !ok || projectID == "" || roleID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_id,role_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), roleID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+}
+
+func (r *projectRoleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*space.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *space.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// stringValues unwraps a list of Terraform strings.
+func stringValues(values []types.String) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, v.ValueString())
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"terraform-provider-jetbrains-space/internal/spacetest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccProjectRoleResource(t *testing.T) {
+	s := newTestServer(t)
+	project := s.AddProject("APP", "App")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if roles := s.Project(project.ID).Roles; len(roles) > 0 {
+				return fmt.Errorf("project still has %d custom roles", len(roles))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing.
+			{
+				Config: s.ProviderConfig() + fmt.Sprintf(`
+resource "jetbrainsspace_project_role" "test" {
+  project_id  = %q
+  name        = "Release Manager"
+  permissions = ["Project.Admin.View", "Repository.Write"]
+}
+`, project.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("jetbrainsspace_project_role.test", "id"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project_role.test", "key", "release-manager"),
+					resource.TestCheckResourceAttr("jetbrainsspace_project_role.test", "permissions.#", "2"),
+					testAccCheckProjectRole(s, project.ID, "Release Manager", "Project.Admin.View", "Repository.Write"),
+				),
+			},
+			// ImportState testing.
+			{
+				ResourceName:      "jetbrainsspace_project_role.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccProjectRoleImportID,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing.
+			{
+				Config: s.ProviderConfig() + fmt.Sprintf(`
+resource "jetbrainsspace_project_role" "test" {
+  project_id  = %q
+  name        = "Release Manager"
+  permissions = ["Repository.Write"]
+}
+`, project.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_project_role.test", "permissions.#", "1"),
+					testAccCheckProjectRole(s, project.ID, "Release Manager", "Repository.Write"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase.
+		},
+	})
+}
+
+func TestAccProjectRoleResource_importInvalidID(t *testing.T) {
+	s := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_project_role" "test" {
+  project_id  = "unknown"
+  name        = "Reviewer"
+  permissions = []
+}
+`,
+				ResourceName:  "jetbrainsspace_project_role.test",
+				ImportState:   true,
+				ImportStateId: "reviewer",
+				ExpectError:   regexp.MustCompile(`project_id,role_id`),
+			},
+		},
+	})
+}
+
+// testAccProjectRoleImportID builds the project_id,role_id import identifier
+// of the role in state.
+func testAccProjectRoleImportID(state *terraform.State) (string, error) {
+	rs, ok := state.RootModule().Resources["jetbrainsspace_project_role.test"]
+	if !ok {
+		return "", fmt.Errorf("resource jetbrainsspace_project_role.test not found")
+	}
+	return rs.Primary.Attributes["project_id"] + "," + rs.Primary.ID, nil
+}
+
+// testAccCheckProjectRole verifies that the project has exactly one custom
+// role, with the given name and permissions.
+func testAccCheckProjectRole(s *spacetest.Server, projectID, name string, permissions ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		project := s.Project(projectID)
+		if project == nil {
+			return fmt.Errorf("project %s not found in Space", projectID)
+		}
+		if len(project.Roles) != 1 {
+			return fmt.Errorf("project has %d custom roles, want 1", len(project.Roles))
+		}
+		for _, role := range project.Roles {
+			if role.Name != name {
+				return fmt.Errorf("role is named %q, want %q", role.Name, name)
+			}
+			got := append([]string(nil), role.Permissions...)
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(permissions, ",") {
+				return fmt.Errorf("role has permissions %v, want %v", got, permissions)
+			}
+		}
+		return nil
+	}
+}
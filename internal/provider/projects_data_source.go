@@ -6,10 +6,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
+
 	space "terraform-provider-jetbrains-space/internal/api"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -34,6 +38,30 @@ func (d *ProjectDataSource) Metadata(ctx context.Context, req datasource.Metadat
 func (d *ProjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return projects whose name matches this regular expression. Matched against every project the other filters return, so it doesn't reduce the number of API requests.",
+			},
+			"key_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return projects whose key starts with this prefix.",
+			},
+			"archived": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return archived projects when true, or active ones when false.",
+			},
+			"private": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return private projects when true, or public ones when false.",
+			},
+			"member_team": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return projects this team is a member of.",
+			},
+			"starred": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return projects starred by the authenticated user when true.",
+			},
 			"projects": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -47,6 +75,33 @@ func (d *ProjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 						"key": schema.StringAttribute{
 							Computed: true,
 						},
+						"created_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the project was created, in RFC 3339 format.",
+						},
+						"latest_repository_activity": schema.StringAttribute{
+							Computed:    true,
+							Description: "When any repository of the project last changed, in RFC 3339 format. Null if the project has no repository activity.",
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"archived": schema.BoolAttribute{
+							Computed: true,
+						},
+						"private": schema.BoolAttribute{
+							Computed: true,
+						},
+						"admins": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Usernames of the project administrators.",
+						},
+						"member_teams": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Names of the teams that are members of the project.",
+						},
 					},
 				},
 			},
@@ -78,7 +133,37 @@ func (d *ProjectDataSource) Configure(ctx context.Context, req datasource.Config
 // Read refreshes the Terraform state with the latest data.
 func (d *ProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state ProjectDataSourceModel
-	projects, err := d.client.GetProjects()
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Regular Expression",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Space can only narrow the list by a search term and by stars; every
+	// other filter is applied to what comes back. The term search matches
+	// project keys, so a key prefix is safe to push down. A name regex is
+	// not: even its literal prefix says nothing about which words the term
+	// search would match.
+	filter := space.ProjectFilter{
+		Term:    state.KeyPrefix.ValueString(),
+		Starred: state.Starred.ValueBool(),
+	}
+
+	projects, err := d.client.GetProjects(ctx, filter)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Projects",
@@ -88,20 +173,63 @@ func (d *ProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	// Map response body to model.
+	state.Projects = []ProjectsModel{}
 	for _, project := range projects.AllProjects {
+		if !projectMatches(state, nameRegex, project) {
+			continue
+		}
+
 		projectState := ProjectsModel{
-			Name: types.StringValue(project.Name),
-			ID:   types.StringValue(project.ID),
-			Key:  types.StringValue(project.Key.Key),
+			Name:           types.StringValue(project.Name),
+			ID:             types.StringValue(project.ID),
+			Key:            types.StringValue(project.Key.Key),
+			CreatedAt:      dateTimeValue(&project.CreatedAt),
+			LatestActivity: dateTimeValue(project.LatestRepositoryActivity),
+			Description:    types.StringValue(project.Description),
+			Archived:       types.BoolValue(project.Archived),
+			Private:        types.BoolValue(project.Private),
+			Admins:         []types.String{},
+			MemberTeams:    []types.String{},
+		}
+		for _, admin := range project.Admins {
+			projectState.Admins = append(projectState.Admins, types.StringValue(admin.Username))
+		}
+		for _, team := range project.MemberTeams {
+			projectState.MemberTeams = append(projectState.MemberTeams, types.StringValue(team.Name))
 		}
 
 		state.Projects = append(state.Projects, projectState)
 	}
 
 	// Set state.
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
+
+// projectMatches applies the filters Space can't evaluate itself.
+func projectMatches(filters ProjectDataSourceModel, nameRegex *regexp.Regexp, project space.Project) bool {
+	if nameRegex != nil && !nameRegex.MatchString(project.Name) {
+		return false
+	}
+	if !strings.HasPrefix(project.Key.Key, filters.KeyPrefix.ValueString()) {
+		return false
+	}
+	if !filters.Archived.IsNull() && project.Archived != filters.Archived.ValueBool() {
+		return false
+	}
+	if !filters.Private.IsNull() && project.Private != filters.Private.ValueBool() {
+		return false
+	}
+	if team := filters.MemberTeam.ValueString(); team != "" {
+		for _, t := range project.MemberTeams {
+			if t.Name == team {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
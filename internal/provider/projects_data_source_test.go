@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"terraform-provider-jetbrains-space/internal/spacetest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccProjectsDataSource(t *testing.T) {
+	s := newTestServer(t)
+	s.AddProject("WEB", "Storefront")
+	s.AddProject("WEBAPI", "Storefront API")
+	s.AddProject("OPS", "Operations").Archived = true
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The name regex is applied by the provider, never sent as a
+			// search term.
+			{
+				Config: s.ProviderConfig() + `
+data "jetbrainsspace_projects" "test" {
+  name_regex = "front( API)?$"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetbrainsspace_projects.test", "projects.#", "2"),
+					resource.TestCheckResourceAttr("data.jetbrainsspace_projects.test", "projects.0.key", "WEB"),
+					resource.TestCheckResourceAttr("data.jetbrainsspace_projects.test", "projects.1.key", "WEBAPI"),
+					testAccCheckProjectSearchTerms(s, ""),
+				),
+			},
+			{
+				Config: s.ProviderConfig() + `
+data "jetbrainsspace_projects" "test" {
+  key_prefix = "WEBA"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetbrainsspace_projects.test", "projects.#", "1"),
+					resource.TestCheckResourceAttr("data.jetbrainsspace_projects.test", "projects.0.name", "Storefront API"),
+					testAccCheckProjectSearchTerms(s, "", "WEBA"),
+				),
+			},
+			{
+				Config: s.ProviderConfig() + `
+data "jetbrainsspace_projects" "test" {
+  archived = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetbrainsspace_projects.test", "projects.#", "1"),
+					resource.TestCheckResourceAttr("data.jetbrainsspace_projects.test", "projects.0.key", "OPS"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckProjectSearchTerms verifies that every project listing so far
+// searched for one of the given terms, where "" means no search term.
+func testAccCheckProjectSearchTerms(s *spacetest.Server, terms ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		allowed := map[string]bool{}
+		for _, term := range terms {
+			allowed[term] = true
+		}
+		for _, r := range s.Requests() {
+			if r.Method != "GET" || r.Path != "/api/http/projects" {
+				continue
+			}
+			query, err := url.ParseQuery(r.Query)
+			if err != nil {
+				return err
+			}
+			if term := query.Get("term"); !allowed[term] {
+				return fmt.Errorf("listed projects with search term %q, want one of %q", term, terms)
+			}
+		}
+		return nil
+	}
+}
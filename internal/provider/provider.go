@@ -2,7 +2,9 @@ package provider
 
 import (
 	"context"
+	"math"
 	"os"
+	"time"
 
 	space "terraform-provider-jetbrains-space/internal/api"
 
@@ -10,9 +12,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -40,20 +44,64 @@ func (p *jetbrainsSpaceProvider) Schema(_ context.Context, _ provider.SchemaRequ
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "Address of the Space organization, e.g. myorg.jetbrains.space. May also be set with JETBRAINS_SPACE_HOST.",
 			},
 			"token": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Permanent token or personal access token. May also be set with JETBRAINS_SPACE_TOKEN.",
+			},
+			"client_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Client ID of a Space application, used with client_secret instead of token. May also be set with JETBRAINS_SPACE_CLIENT_ID.",
+			},
+			"client_secret": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Client secret of the Space application identified by client_id. May also be set with JETBRAINS_SPACE_CLIENT_SECRET.",
+			},
+			"scope": schema.StringAttribute{
+				Optional:    true,
+				Description: "OAuth scope requested for the application's access tokens. Defaults to all permissions granted to the application. May also be set with JETBRAINS_SPACE_SCOPE.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "How many times a throttled or transiently failing idempotent request is retried. Defaults to 3; 0 disables retries.",
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Upper bound, in seconds, on the wait between retries, including waits requested by Retry-After. Defaults to 30.",
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Sustained rate of API requests shared by all resources. Defaults to 10; 0 disables rate limiting.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of API requests in flight at once. Defaults to 8; 0 removes the cap.",
 			},
 		},
 	}
 }
 
+// Environment variables read when the matching attribute isn't configured.
+const (
+	envHost         = "JETBRAINS_SPACE_HOST"
+	envToken        = "JETBRAINS_SPACE_TOKEN"
+	envClientID     = "JETBRAINS_SPACE_CLIENT_ID"
+	envClientSecret = "JETBRAINS_SPACE_CLIENT_SECRET"
+	envScope        = "JETBRAINS_SPACE_SCOPE"
+
+	// Deprecated names from earlier releases, still honoured with a warning.
+	legacyEnvHost  = "jetbrainsSpace_HOST"
+	legacyEnvToken = "jetbrainsSpace_TOKEN"
+)
+
 // Configure prepares a jetbrainsSpace API client for data sources and resources.
 func (p *jetbrainsSpaceProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	// Retrieve provider data from configuration.
 	tflog.Info(ctx, "Configurating Jetbrains Space provider")
-	tflog.Info(ctx, "MADE IT HERE")
 	var config jetbrainsSpaceProviderModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
@@ -69,35 +117,71 @@ func (p *jetbrainsSpaceProvider) Configure(ctx context.Context, req provider.Con
 			path.Root("host"),
 			"Unknown jetbrainsSpace API Host",
 			"The provider cannot create the jetbrainsSpace API client as there is an unknown configuration value for the jetbrainsSpace API host. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the jetbrainsSpace_HOST environment variable.",
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the JETBRAINS_SPACE_HOST environment variable.",
 		)
 	}
 
 	if config.Token.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("token"),
-			"Unknown jetbrainsSpace API Username",
-			"The provider cannot create the jetbrainsSpace API client as there is an unknown configuration value for the jetbrainsSpace API username. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the jetbrainsSpace_USERNAME environment variable.",
+			"Unknown jetbrainsSpace API Token",
+			"The provider cannot create the jetbrainsSpace API client as there is an unknown configuration value for the jetbrainsSpace API token. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the JETBRAINS_SPACE_TOKEN environment variable.",
 		)
 	}
 
+	for _, attr := range []struct {
+		name  string
+		env   string
+		value types.String
+	}{
+		{"client_id", envClientID, config.ClientID},
+		{"client_secret", envClientSecret, config.ClientSecret},
+		{"scope", envScope, config.Scope},
+	} {
+		if attr.value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attr.name),
+				"Unknown jetbrainsSpace API Credentials",
+				"The provider cannot create the jetbrainsSpace API client as there is an unknown configuration value for "+attr.name+". "+
+					"Either target apply the source of the value first, set the value statically in the configuration, or use the "+attr.env+" environment variable.",
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
+	// with Terraform configuration value if set. The host and token
+	// variables are only looked up when needed, so a leftover legacy
+	// variable doesn't warn about a value the configuration overrides.
+
+	host := config.Host.ValueString()
+	token := config.Token.ValueString()
+	clientID := os.Getenv(envClientID)
+	clientSecret := os.Getenv(envClientSecret)
+	scope := os.Getenv(envScope)
+
+	if config.Host.IsNull() {
+		host = lookupEnv(&resp.Diagnostics, envHost, legacyEnvHost)
+	}
 
-	host := os.Getenv("jetbrainsSpace_HOST")
-	token := os.Getenv("jetbrainsSpace_TOKEN")
+	if config.Token.IsNull() {
+		token = lookupEnv(&resp.Diagnostics, envToken, legacyEnvToken)
+	}
+
+	if !config.ClientID.IsNull() {
+		clientID = config.ClientID.ValueString()
+	}
 
-	if !config.Host.IsNull() {
-		host = config.Host.ValueString()
+	if !config.ClientSecret.IsNull() {
+		clientSecret = config.ClientSecret.ValueString()
 	}
 
-	if !config.Token.IsNull() {
-		token = config.Token.ValueString()
+	if !config.Scope.IsNull() {
+		scope = config.Scope.ValueString()
 	}
 
 	// If any of the expected configurations are missing, return
@@ -108,29 +192,105 @@ func (p *jetbrainsSpaceProvider) Configure(ctx context.Context, req provider.Con
 			path.Root("host"),
 			"Missing jetbrainsSpace API Host",
 			"The provider cannot create the jetbrainsSpace API client as there is a missing or empty value for the jetbrainsSpace API host. "+
-				"Set the host value in the configuration or use the jetbrainsSpace_HOST environment variable. "+
+				"Set the host value in the configuration or use the JETBRAINS_SPACE_HOST environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
+	} else if normalized, err := space.NormalizeHost(host); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host"),
+			"Invalid jetbrainsSpace API Host",
+			"The provider cannot create the jetbrainsSpace API client as the host "+host+" is not a valid Space URL: "+err.Error(),
+		)
+	} else {
+		host = normalized
+	}
+
+	useCredentials := clientID != "" || clientSecret != ""
+
+	if useCredentials && token != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Conflicting jetbrainsSpace API Credentials",
+			"Configure either token or client_id and client_secret, not both, including through the environment.",
+		)
+	}
+
+	if useCredentials && (clientID == "" || clientSecret == "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_secret"),
+			"Incomplete jetbrainsSpace API Credentials",
+			"client_id and client_secret must be set together.",
+		)
 	}
 
-	if token == "" {
+	if token == "" && !useCredentials {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("token"),
-			"Missing jetbrainsSpace API Username",
-			"The provider cannot create the jetbrainsSpace API client as there is a missing or empty value for the jetbrainsSpace API username. "+
-				"Set the username value in the configuration or use the jetbrainsSpace_USERNAME environment variable. "+
+			"Missing jetbrainsSpace API Token",
+			"The provider cannot create the jetbrainsSpace API client as there is a missing or empty value for the jetbrainsSpace API token. "+
+				"Set the token value in the configuration or use the JETBRAINS_SPACE_TOKEN environment variable, "+
+				"or configure client_id and client_secret for a Space application. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
+	if !config.MaxRetries.IsNull() && config.MaxRetries.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid jetbrainsSpace API Retry Count",
+			"max_retries must not be negative.",
+		)
+	}
+
+	if !config.RetryMaxWait.IsNull() && config.RetryMaxWait.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_max_wait"),
+			"Invalid jetbrainsSpace API Retry Wait",
+			"retry_max_wait must be at least 1 second.",
+		)
+	}
+
+	if !config.RequestsPerSecond.IsNull() && config.RequestsPerSecond.ValueFloat64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid jetbrainsSpace API Rate Limit",
+			"requests_per_second must not be negative.",
+		)
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() && config.MaxConcurrentRequests.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid jetbrainsSpace API Concurrency",
+			"max_concurrent_requests must not be negative.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Never let credentials reach TF_LOG output, even if a field or message
+	// below ends up containing one.
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "token", "client_secret")
+	var secrets []string
+	for _, secret := range []string{token, clientSecret} {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	if len(secrets) > 0 {
+		ctx = tflog.MaskLogStrings(ctx, secrets...)
+	}
 	ctx = tflog.SetField(ctx, "jetbrainspsace_host", host)
-	ctx = tflog.SetField(ctx, "jetbrainspsace_token", token)
 	// Create a new jetbrainsSpace client using the configuration values.
-	client, err := space.NewClient(host, token)
+	var client *space.Client
+	var err error
+	if useCredentials {
+		client, err = space.NewClientWithCredentials(host, clientID, clientSecret, scope)
+	} else {
+		client, err = space.NewClient(host, token)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create jetbrainsSpace API Client",
@@ -141,17 +301,37 @@ func (p *jetbrainsSpaceProvider) Configure(ctx context.Context, req provider.Con
 		return
 	}
 
+	if !config.MaxRetries.IsNull() {
+		client.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.RetryMaxWait.IsNull() {
+		client.RetryWaitMax = time.Duration(config.RetryMaxWait.ValueInt64()) * time.Second
+		if client.RetryWaitMin > client.RetryWaitMax {
+			client.RetryWaitMin = client.RetryWaitMax
+		}
+	}
+
+	if !config.RequestsPerSecond.IsNull() {
+		rate := config.RequestsPerSecond.ValueFloat64()
+		client.SetRateLimit(rate, int(math.Ceil(rate)))
+	}
+	if !config.MaxConcurrentRequests.IsNull() {
+		client.SetMaxConcurrency(int(config.MaxConcurrentRequests.ValueInt64()))
+	}
+
 	// Make the jetbrainsSpace client available during DataSource and Resource
 	// type Configure methods.
 	resp.DataSourceData = client
 	resp.ResourceData = client
-	tflog.Info(ctx, "Configured Jetbrains Space client this is a test", map[string]any{"success": true})
+	tflog.Info(ctx, "Configured Jetbrains Space client", map[string]any{"success": true})
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *jetbrainsSpaceProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		projectsDataSource,
+		NewProjectDataSource,
+		NewRepositoriesDataSource,
 	}
 }
 
@@ -160,5 +340,24 @@ func (p *jetbrainsSpaceProvider) Resources(_ context.Context) []func() resource.
 	return []func() resource.Resource{
 		NewProjectResource,
 		NewRepoResource,
+		NewProjectMemberResource,
+		NewProjectTeamMemberResource,
+		NewProjectRoleResource,
+	}
+}
+
+// lookupEnv reads name, falling back to the deprecated legacy variable with a
+// warning so existing pipelines keep working while they migrate.
+func lookupEnv(diags *diag.Diagnostics, name, legacy string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	value := os.Getenv(legacy)
+	if value != "" {
+		diags.AddWarning(
+			"Deprecated Environment Variable",
+			"The "+legacy+" environment variable is deprecated and will be removed in a future release. Use "+name+" instead.",
+		)
 	}
+	return value
 }
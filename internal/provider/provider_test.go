@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	space "terraform-provider-jetbrains-space/internal/api"
+	"terraform-provider-jetbrains-space/internal/spacetest"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testAccProtoV6ProviderFactories are used to instantiate a provider during
+// acceptance testing. The factory function will be invoked for every Terraform
+// CLI command executed to create a provider server to which the CLI can
+// reattach.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"jetbrainsspace": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// newTestServer starts a fake Space organization for one test. Configurations
+// point the provider at it with ProviderConfig.
+func newTestServer(t *testing.T) *spacetest.Server {
+	t.Helper()
+	s := spacetest.NewServer()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// configure runs the provider's Configure with the given attributes set and
+// every other attribute null.
+func configure(t *testing.T, attrs map[string]string) *provider.ConfigureResponse {
+	t.Helper()
+	ctx := context.Background()
+	p := New("test")()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := map[string]tftypes.Value{}
+	for name, typ := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	for name, value := range attrs {
+		values[name] = tftypes.NewValue(tftypes.String, value)
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}, resp)
+	return resp
+}
+
+func TestConfigureEnvironment(t *testing.T) {
+	cases := []struct {
+		name         string
+		env          map[string]string
+		attrs        map[string]string
+		wantHost     string
+		wantToken    string
+		wantWarnings int
+	}{
+		{
+			name:      "configuration only",
+			attrs:     map[string]string{"host": "myorg.jetbrains.space", "token": "config-token"},
+			wantHost:  "https://myorg.jetbrains.space",
+			wantToken: "config-token",
+		},
+		{
+			name:      "environment",
+			env:       map[string]string{envHost: "env.jetbrains.space", envToken: "env-token"},
+			wantHost:  "https://env.jetbrains.space",
+			wantToken: "env-token",
+		},
+		{
+			name:      "configuration overrides environment",
+			env:       map[string]string{envHost: "env.jetbrains.space", envToken: "env-token"},
+			attrs:     map[string]string{"host": "myorg.jetbrains.space", "token": "config-token"},
+			wantHost:  "https://myorg.jetbrains.space",
+			wantToken: "config-token",
+		},
+		{
+			name:         "legacy environment",
+			env:          map[string]string{legacyEnvHost: "legacy.jetbrains.space", legacyEnvToken: "legacy-token"},
+			wantHost:     "https://legacy.jetbrains.space",
+			wantToken:    "legacy-token",
+			wantWarnings: 2,
+		},
+		{
+			name:      "legacy environment overridden by configuration",
+			env:       map[string]string{legacyEnvHost: "legacy.jetbrains.space", legacyEnvToken: "legacy-token"},
+			attrs:     map[string]string{"host": "myorg.jetbrains.space", "token": "config-token"},
+			wantHost:  "https://myorg.jetbrains.space",
+			wantToken: "config-token",
+		},
+		{
+			name:         "legacy token only",
+			env:          map[string]string{legacyEnvToken: "legacy-token"},
+			attrs:        map[string]string{"host": "myorg.jetbrains.space"},
+			wantHost:     "https://myorg.jetbrains.space",
+			wantToken:    "legacy-token",
+			wantWarnings: 1,
+		},
+		{
+			name:      "legacy environment shadowed by the new names",
+			env:       map[string]string{envHost: "env.jetbrains.space", envToken: "env-token", legacyEnvHost: "legacy.jetbrains.space", legacyEnvToken: "legacy-token"},
+			wantHost:  "https://env.jetbrains.space",
+			wantToken: "env-token",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range []string{envHost, envToken, envClientID, envClientSecret, envScope, legacyEnvHost, legacyEnvToken} {
+				t.Setenv(name, tc.env[name])
+			}
+
+			resp := configure(t, tc.attrs)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+			}
+			if got := resp.Diagnostics.WarningsCount(); got != tc.wantWarnings {
+				t.Errorf("got %d warnings, want %d: %v", got, tc.wantWarnings, resp.Diagnostics.Warnings())
+			}
+
+			client, ok := resp.ResourceData.(*space.Client)
+			if !ok {
+				t.Fatalf("ResourceData is %T, want *space.Client", resp.ResourceData)
+			}
+			if client.HostURL != tc.wantHost {
+				t.Errorf("host = %q, want %q", client.HostURL, tc.wantHost)
+			}
+			if client.Token != tc.wantToken {
+				t.Errorf("token = %q, want %q", client.Token, tc.wantToken)
+			}
+		})
+	}
+}
+
+func TestConfigureRejectsInvalidHost(t *testing.T) {
+	t.Setenv(envHost, "")
+	t.Setenv(legacyEnvHost, "")
+
+	resp := configure(t, map[string]string{"host": "ftp://myorg.jetbrains.space", "token": "token"})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for an ftp host")
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -153,7 +154,7 @@ func (r *repoResource) Create(ctx context.Context, req resource.CreateRequest, r
 		DefaultSetup:  true,
 	}
 
-	repo, err := r.client.CreateRepository(repoName, projectID, repoData)
+	repo, err := r.client.CreateRepository(ctx, repoName, projectID, repoData)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating repo - "+plan.Name.String()+" ",
@@ -194,7 +195,13 @@ func (r *repoResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Get refreshed repo data.
-	repo, err := r.client.GetRepository(state.Name.ValueString(), state.ProjectID.ValueString())
+	repo, err := r.client.GetRepository(ctx, state.Name.ValueString(), state.ProjectID.ValueString())
+	if space.IsNotFound(err) {
+		// Either the repo or its project was deleted outside of Terraform.
+		tflog.Warn(ctx, "Repository not found, removing from state", map[string]any{"name": state.Name.ValueString(), "project_id": state.ProjectID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Jetbrains Space repo"+state.Name.ValueString(),
@@ -203,7 +210,7 @@ func (r *repoResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	branch, err := r.client.GetRepoProtectedBranches(state.ProjectID.ValueString(), state.Name.ValueString())
+	branch, err := r.client.GetRepoProtectedBranches(ctx, state.ProjectID.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating protected branches - "+state.Name.ValueString()+" ",
@@ -214,6 +221,13 @@ func (r *repoResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Overwrite items with refreshed state.
 	state.ID = types.StringValue(repo.ID)
 	state.Name = types.StringValue(repo.Name)
+	state.Description = types.StringValue(repo.Description)
+	if branch := strings.TrimPrefix(repo.DefaultBranch.Head, "refs/heads/"); branch != "" {
+		state.DefaultBranch = types.StringValue(branch)
+	}
+	if state.Protected.IsNull() {
+		state.Protected = types.BoolValue(false)
+	}
 
 	var protectedBranchesState []repoSettingsBranchModel
 
@@ -288,7 +302,7 @@ func (r *repoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		)
 	}
 	if different {
-		_, err := r.client.UpdateRepositoryDescription(projectID, name, value)
+		_, err := r.client.UpdateRepositoryDescription(ctx, projectID, name, value)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating Space repo; "+repo.ID+" is the value...",
@@ -306,7 +320,7 @@ func (r *repoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		)
 	}
 	if different {
-		_, err := r.client.UpdateRepositoryDefaultBranch(projectID, name, value)
+		_, err := r.client.UpdateRepositoryDefaultBranch(ctx, projectID, name, value)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating Space repo;"+repo.ID,
@@ -324,7 +338,7 @@ func (r *repoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		)
 		return
 	}
-	p, err := r.client.GetRepository(name, projectID)
+	p, err := r.client.GetRepository(ctx, name, projectID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Space project"+projectID,
@@ -362,7 +376,7 @@ func (r *repoResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 			"",
 		)
 	} else {
-		err := r.client.DeleteRepository(state.ProjectID.ValueString(), state.Name.ValueString())
+		err := r.client.DeleteRepository(ctx, state.ProjectID.ValueString(), state.Name.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Deleting Space Repo"+state.Name.ValueString(),
@@ -373,19 +387,34 @@ func (r *repoResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+// ImportState accepts PROJECT_KEY/repo-name, as shown in clone URLs,
+// project_id/repo-name, or the older name,project_id form.
 func (r *repoResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := strings.Split(req.ID, ",")
-
-	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+	var name, projectID string
+	if project, repo, ok := strings.Cut(req.ID, "/"); ok && project != "" && repo != "" {
+		p, err := lookupProject(ctx, r.client, project)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error importing repository "+req.ID,
+				"Could not find project "+project+": "+err.Error(),
+			)
+			return
+		}
+		name, projectID = repo, p.ID
+	} else if repo, project, ok := strings.Cut(req.ID, ","); ok && repo != "" && project != "" {
+		name, projectID = repo, project
+	} else {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: attr_one,attr_two. Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: PROJECT_KEY/repo-name, project_id/repo-name or repo-name,project_id. Got: %q", req.ID),
 		)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), idParts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), idParts[1])...)
+	// The remaining attributes are filled in by Read.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protected"), false)...)
 }
 
 func (r *repoResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -436,7 +465,7 @@ func (r *repoResource) UpdateRepositoryProtectedBranches(ctx context.Context, Pr
 			var err error
 
 			if job.Id.IsUnknown() {
-				JobID, err = r.client.GetJobIDFromName(plan.ProjectID.ValueString(), plan.Name.ValueString(), plan.DefaultBranch.ValueString(), job.Name.ValueString())
+				JobID, err = r.client.GetJobIDFromName(ctx, plan.ProjectID.ValueString(), plan.Name.ValueString(), plan.DefaultBranch.ValueString(), job.Name.ValueString())
 				if err != nil {
 					return repoResourceModel{}, err
 				}
@@ -477,9 +506,9 @@ func (r *repoResource) UpdateRepositoryProtectedBranches(ctx context.Context, Pr
 
 	}
 
-	branch, err := r.client.UpdateRepoProtectedBranches(data, ProjectID, Repository)
+	branch, err := r.client.UpdateRepoProtectedBranches(ctx, data, ProjectID, Repository)
 	if err != nil {
-		return repoResourceModel{}, fmt.Errorf("Could not update repos protected branches, unexpected error: " + err.Error())
+		return repoResourceModel{}, fmt.Errorf("could not update repos protected branches: %w", err)
 	}
 
 	for k, v := range branch.ProtectedBranches {
@@ -521,9 +550,9 @@ func (r *repoResource) ReadAutomationJobs(data space.ProtectedBranchesReq, ctx c
 	var automationJobs []repoSettingsBranchModelJobs
 	for _, jobID := range data.QualityGate.AutomationJobs {
 
-		jobName, err := r.client.GetJobName(ProjectID, jobID)
+		jobName, err := r.client.GetJobName(ctx, ProjectID, jobID)
 		if err != nil {
-			return []repoSettingsBranchModelJobs{}, fmt.Errorf("problem getting the job name for id " + jobID + ": " + err.Error())
+			return []repoSettingsBranchModelJobs{}, fmt.Errorf("problem getting the job name for id %s: %w", jobID, err)
 		}
 
 		automationJobs = append(automationJobs, repoSettingsBranchModelJobs{
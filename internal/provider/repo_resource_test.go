@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRepoResource(t *testing.T) {
+	s := newTestServer(t)
+	project := s.AddProject("APP", "App")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(state *terraform.State) error {
+			if repos := s.Project(project.ID).Repos; len(repos) > 0 {
+				return fmt.Errorf("repositories left behind: %v", repos)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing.
+			{
+				Config: s.ProviderConfig() + fmt.Sprintf(`
+resource "jetbrainsspace_repository" "test" {
+  project_id     = %q
+  name           = "backend"
+  description    = "API"
+  default_branch = "develop"
+
+  protected_branches = [{
+    pattern = ["main"]
+    quality_gate = {
+      approvals = [{
+        approved_by   = ["alice"]
+        min_approvals = 1
+      }]
+    }
+  }]
+}
+`, project.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_repository.test", "name", "backend"),
+					resource.TestCheckResourceAttr("jetbrainsspace_repository.test", "description", "API"),
+					resource.TestCheckResourceAttr("jetbrainsspace_repository.test", "default_branch", "develop"),
+					resource.TestCheckResourceAttr("jetbrainsspace_repository.test", "protected_branches.0.pattern.0", "main"),
+					resource.TestCheckResourceAttr("jetbrainsspace_repository.test", "protected_branches.0.quality_gate.approvals.0.approved_by.0", "alice"),
+					resource.TestCheckResourceAttrSet("jetbrainsspace_repository.test", "id"),
+				),
+			},
+			// ImportState testing in each supported format.
+			{
+				ResourceName:            "jetbrainsspace_repository.test",
+				ImportState:             true,
+				ImportStateId:           "APP/backend",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				ResourceName:            "jetbrainsspace_repository.test",
+				ImportState:             true,
+				ImportStateId:           project.ID + "/backend",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				ResourceName:            "jetbrainsspace_repository.test",
+				ImportState:             true,
+				ImportStateId:           "backend," + project.ID,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing.
+			{
+				Config: s.ProviderConfig() + fmt.Sprintf(`
+resource "jetbrainsspace_repository" "test" {
+  project_id     = %q
+  name           = "backend"
+  description    = "Service API"
+  default_branch = "main"
+}
+`, project.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jetbrainsspace_repository.test", "description", "Service API"),
+					resource.TestCheckResourceAttr("jetbrainsspace_repository.test", "default_branch", "main"),
+					resource.TestCheckNoResourceAttr("jetbrainsspace_repository.test", "protected_branches.#"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase.
+		},
+	})
+}
+
+func TestAccRepoResource_importUnknownProject(t *testing.T) {
+	s := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.ProviderConfig() + `
+resource "jetbrainsspace_repository" "test" {
+  project_id = "unknown"
+  name       = "backend"
+}
+`,
+				ResourceName:  "jetbrainsspace_repository.test",
+				ImportState:   true,
+				ImportStateId: "NOPE/backend",
+				ExpectError:   regexp.MustCompile(`Could not find project NOPE`),
+			},
+		},
+	})
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	space "terraform-provider-jetbrains-space/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &repositoriesDataSource{}
+	_ datasource.DataSourceWithConfigure = &repositoriesDataSource{}
+)
+
+// NewRepositoriesDataSource is a helper function to simplify the provider implementation.
+func NewRepositoriesDataSource() datasource.DataSource {
+	return &repositoriesDataSource{}
+}
+
+// repositoriesDataSource lists the repositories of a project.
+type repositoriesDataSource struct {
+	client *space.Client
+}
+
+func (d *repositoriesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repositories"
+}
+
+func (d *repositoriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the repositories of a project.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return repositories whose name matches this regular expression.",
+			},
+			"state": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return repositories in this state, such as `Ready`.",
+			},
+			"repositories": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"state": schema.StringAttribute{
+							Computed: true,
+						},
+						"default_branch_head": schema.StringAttribute{
+							Computed:    true,
+							Description: "Full ref of the default branch, such as `refs/heads/main`.",
+						},
+						"default_branch_ref": schema.StringAttribute{
+							Computed:    true,
+							Description: "Commit the default branch points to.",
+						},
+						"http_url": schema.StringAttribute{
+							Computed:    true,
+							Description: "URL to clone the repository over HTTPS.",
+						},
+						"ssh_url": schema.StringAttribute{
+							Computed:    true,
+							Description: "URL to clone the repository over SSH.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *repositoriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*space.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *space.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *repositoriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state repositoriesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Regular Expression",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	projectID := state.ProjectID.ValueString()
+	repos, err := d.client.ListRepositories(ctx, projectID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Repositories of project "+projectID,
+			err.Error(),
+		)
+		return
+	}
+
+	state.Repositories = []repositoriesModel{}
+	for _, repo := range repos {
+		if nameRegex != nil && !nameRegex.MatchString(repo.Name) {
+			continue
+		}
+		if !state.State.IsNull() && repo.State != state.State.ValueString() {
+			continue
+		}
+
+		urls, err := d.client.GetRepositoryURL(ctx, repo.Name, projectID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Clone URLs of repository "+repo.Name,
+				err.Error(),
+			)
+			return
+		}
+
+		state.Repositories = append(state.Repositories, repositoriesModel{
+			ID:                types.StringValue(repo.ID),
+			Name:              types.StringValue(repo.Name),
+			Description:       types.StringValue(repo.Description),
+			State:             types.StringValue(repo.State),
+			DefaultBranchHead: types.StringValue(repo.DefaultBranch.Head),
+			DefaultBranchRef:  types.StringValue(repo.DefaultBranch.Ref),
+			HTTPURL:           types.StringValue(urls.HTTPURL),
+			SSHURL:            types.StringValue(urls.SSHURL),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRepositoriesDataSource(t *testing.T) {
+	s := newTestServer(t)
+	project := s.AddProject("APP", "App")
+	for _, name := range []string{"api", "docs", "web"} {
+		s.AddRepository(project.ID, name)
+	}
+	host := strings.TrimPrefix(s.URL, "http://")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.ProviderConfig() + fmt.Sprintf(`
+data "jetbrainsspace_repositories" "test" {
+  project_id = %q
+}
+`, project.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetbrainsspace_repositories.test", "repositories.#", "3"),
+					resource.TestCheckResourceAttr("data.jetbrainsspace_repositories.test", "repositories.0.name", "api"),
+					resource.TestCheckResourceAttr("data.jetbrainsspace_repositories.test", "repositories.0.http_url", s.URL+"/git/APP/api.git"),
+					resource.TestCheckResourceAttr("data.jetbrainsspace_repositories.test", "repositories.2.http_url", s.URL+"/git/APP/web.git"),
+					resource.TestCheckResourceAttr("data.jetbrainsspace_repositories.test", "repositories.2.ssh_url", "ssh://git@"+host+"/APP/web.git"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// projectKeyPattern mirrors the rule Space applies to project keys: upper
+// case letters, digits, hyphens and underscores, starting with a letter or
+// digit.
+var projectKeyPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]*$`)
+
+var _ validator.String = projectKeyValidator{}
+
+// projectKeyValidator rejects keys Space would refuse, so the error shows up
+// at plan time instead of halfway through an apply.
+type projectKeyValidator struct{}
+
+func (v projectKeyValidator) Description(_ context.Context) string {
+	return "must contain only upper case letters, digits, hyphens and underscores, and start with a letter or digit"
+}
+
+func (v projectKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v projectKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !projectKeyPattern.MatchString(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Project Key",
+			"Project key "+req.ConfigValue.String()+" "+v.Description(ctx)+".",
+		)
+	}
+}
+
+var _ validator.String = stringOneOfValidator{}
+
+// stringOneOfValidator restricts an attribute to a fixed set of values.
+type stringOneOfValidator struct {
+	values []string
+}
+
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return "must be one of: " + strings.Join(v.values, ", ")
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, value := range v.values {
+		if req.ConfigValue.ValueString() == value {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		"Value "+req.ConfigValue.String()+" "+v.Description(ctx)+".",
+	)
+}
+
+var _ validator.List = projectRolesValidator{}
+
+// projectRolesValidator keeps `roles` of a project from granting the built-in
+// roles, which the membership attributes manage, or listing a role twice.
+// Either would leave two entries fighting over the same grants.
+type projectRolesValidator struct{}
+
+func (v projectRolesValidator) Description(_ context.Context) string {
+	return "must not list the built-in roles member and admin, or the same role twice"
+}
+
+func (v projectRolesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v projectRolesValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for i, element := range req.ConfigValue.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			continue
+		}
+		role, ok := object.Attributes()["role"].(types.String)
+		if !ok || role.IsNull() || role.IsUnknown() {
+			continue
+		}
+
+		rolePath := req.Path.AtListIndex(i).AtName("role")
+		switch key := role.ValueString(); {
+		case key == "member" || key == "admin":
+			resp.Diagnostics.AddAttributeError(
+				rolePath,
+				"Built-in Project Role",
+				"Role "+role.String()+" is granted through `members`, `member_teams`, `admins` and `admin_teams`, not `roles`.",
+			)
+		case seen[key]:
+			resp.Diagnostics.AddAttributeError(
+				rolePath,
+				"Duplicate Project Role",
+				"Role "+role.String()+" is listed more than once in `roles`; list all of its members and teams in one entry.",
+			)
+		}
+		seen[role.ValueString()] = true
+	}
+}
+
+var _ datasource.ConfigValidator = exactlyOneOfValidator{}
+
+// exactlyOneOfValidator requires exactly one of the given top-level
+// attributes of a data source to be set.
+type exactlyOneOfValidator struct {
+	attributes []string
+}
+
+func (v exactlyOneOfValidator) Description(_ context.Context) string {
+	return "exactly one of " + strings.Join(v.attributes, ", ") + " must be set"
+}
+
+func (v exactlyOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v exactlyOneOfValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	set := 0
+	for _, name := range v.attributes {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if value.IsUnknown() {
+			// Known only at apply time; checked again then.
+			return
+		}
+		if !value.IsNull() {
+			set++
+		}
+	}
+
+	if set != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid Attribute Combination",
+			"Set exactly one of: "+strings.Join(v.attributes, ", ")+".",
+		)
+	}
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProjectKeyValidator(t *testing.T) {
+	cases := []struct {
+		value   types.String
+		wantErr bool
+	}{
+		{value: types.StringNull()},
+		{value: types.StringUnknown()},
+		{value: types.StringValue("APP")},
+		{value: types.StringValue("WEB-APP")},
+		{value: types.StringValue("API_V2")},
+		{value: types.StringValue("2024")},
+		{value: types.StringValue(""), wantErr: true},
+		{value: types.StringValue("app"), wantErr: true},
+		{value: types.StringValue("-APP"), wantErr: true},
+		{value: types.StringValue("_APP"), wantErr: true},
+		{value: types.StringValue("WEB APP"), wantErr: true},
+		{value: types.StringValue("WEB.APP"), wantErr: true},
+		{value: types.StringValue("ÜBER"), wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value.String(), func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			projectKeyValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("key"),
+				ConfigValue: tc.value,
+			}, resp)
+			if got := resp.Diagnostics.HasError(); got != tc.wantErr {
+				t.Errorf("got error %v, want %v: %v", got, tc.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestStringOneOfValidator(t *testing.T) {
+	v := stringOneOfValidator{values: []string{"delete", "archive"}}
+	cases := []struct {
+		value   types.String
+		wantErr bool
+	}{
+		{value: types.StringNull()},
+		{value: types.StringUnknown()},
+		{value: types.StringValue("delete")},
+		{value: types.StringValue("archive")},
+		{value: types.StringValue("Delete"), wantErr: true},
+		{value: types.StringValue(""), wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value.String(), func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			v.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("deletion_policy"),
+				ConfigValue: tc.value,
+			}, resp)
+			if got := resp.Diagnostics.HasError(); got != tc.wantErr {
+				t.Errorf("got error %v, want %v: %v", got, tc.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestProjectRolesValidator(t *testing.T) {
+	roleType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"role":    types.StringType,
+		"members": types.SetType{ElemType: types.StringType},
+		"teams":   types.SetType{ElemType: types.StringType},
+	}}
+	grant := func(role types.String) attr.Value {
+		return types.ObjectValueMust(roleType.AttrTypes, map[string]attr.Value{
+			"role":    role,
+			"members": types.SetValueMust(types.StringType, []attr.Value{types.StringValue("alice")}),
+			"teams":   types.SetNull(types.StringType),
+		})
+	}
+	roles := func(grants ...attr.Value) types.List {
+		return types.ListValueMust(roleType, grants)
+	}
+
+	cases := []struct {
+		name    string
+		value   types.List
+		wantErr bool
+	}{
+		{name: "null", value: types.ListNull(roleType)},
+		{name: "unknown", value: types.ListUnknown(roleType)},
+		{name: "custom roles", value: roles(grant(types.StringValue("reviewer")), grant(types.StringValue("release-manager")))},
+		{name: "unknown role", value: roles(grant(types.StringUnknown()), grant(types.StringUnknown()))},
+		{name: "member", value: roles(grant(types.StringValue("member"))), wantErr: true},
+		{name: "admin", value: roles(grant(types.StringValue("reviewer")), grant(types.StringValue("admin"))), wantErr: true},
+		{name: "duplicate", value: roles(grant(types.StringValue("reviewer")), grant(types.StringValue("reviewer"))), wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &validator.ListResponse{}
+			projectRolesValidator{}.ValidateList(context.Background(), validator.ListRequest{
+				Path:        path.Root("roles"),
+				ConfigValue: tc.value,
+			}, resp)
+			if got := resp.Diagnostics.HasError(); got != tc.wantErr {
+				t.Errorf("got error %v, want %v: %v", got, tc.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,974 @@
+// Package spacetest provides an in-process fake of the parts of the Space
+// HTTP API used by this provider, so the API client and the resources can be
+// exercised without a real Space organization.
+package spacetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	space "terraform-provider-jetbrains-space/internal/api"
+)
+
+const (
+	// Token is the bearer token the fake server accepts.
+	Token = "spacetest-token"
+
+	// Username is the profile Token authenticates as. Like Space, the fake
+	// server makes it an administrator of every project it creates.
+	Username = "spacetest-admin"
+
+	// ClientID and ClientSecret are the application credentials the fake
+	// server exchanges for access tokens on /oauth/token.
+	ClientID     = "spacetest-client"
+	ClientSecret = "spacetest-secret"
+
+	projectsPath = "/api/http/projects"
+)
+
+// Server is a fake Space organization backed by in-memory state.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	nextID       int
+	projects     map[string]*Project
+	jobs         map[string]*AutomationJob
+	accessTokens map[string]bool
+	attachments  map[string][]byte
+	faults       []*Fault
+	requests     []Request
+}
+
+// Project is the fake server's record of a project.
+type Project struct {
+	ID          string
+	Key         string
+	Name        string
+	Description string
+	Private     bool
+	Archived    bool
+	Icon        string
+	// Starred marks the project as starred by the authenticated user.
+	Starred   bool
+	CreatedAt time.Time
+	// LatestRepositoryActivity is when a repository was last created or
+	// changed; zero if never.
+	LatestRepositoryActivity time.Time
+	// Members and Teams map usernames and team names to their role keys.
+	Members map[string]map[string]bool
+	Teams   map[string]map[string]bool
+	Repos   map[string]*Repository
+	// Roles holds the custom roles defined in the project, by ID.
+	Roles map[string]*Role
+}
+
+// Role is the fake server's record of a custom project role.
+type Role struct {
+	ID          string
+	Key         string
+	Name        string
+	Permissions []string
+}
+
+// Repository is the fake server's record of a repository.
+type Repository struct {
+	ID                string
+	Name              string
+	Description       string
+	DefaultBranch     string
+	ProtectedBranches json.RawMessage
+}
+
+// AutomationJob is an automation job registered with AddAutomationJob.
+type AutomationJob struct {
+	ID        string
+	ProjectID string
+	Repo      string
+	Branch    string
+	Name      string
+}
+
+// Fault makes matching requests fail or slow down. Empty Method and Path
+// match everything; Path matches as a prefix of the request path.
+type Fault struct {
+	Method string
+	Path   string
+	// Status, when non-zero, is returned instead of handling the request.
+	Status int
+	// RetryAfter is sent as the Retry-After header with the faulty response.
+	RetryAfter string
+	// Latency delays the response, faulty or not.
+	Latency time.Duration
+	// Times limits how many requests the fault applies to; zero means until
+	// ClearFaults is called.
+	Times int
+}
+
+// Request is a request as seen by the fake server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// NewServer starts a fake Space server. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		projects:     map[string]*Project{},
+		jobs:         map[string]*AutomationJob{},
+		accessTokens: map[string]bool{},
+		attachments:  map[string][]byte{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns an API client authenticated against the fake server.
+func (s *Server) Client() (*space.Client, error) {
+	return space.NewClient(s.URL, Token)
+}
+
+// ProviderConfig returns a provider block pointing at the fake server, for
+// use in acceptance test configurations.
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf("provider \"jetbrainsspace\" {\n  host  = %q\n  token = %q\n}\n", s.URL, Token)
+}
+
+// Inject registers a fault.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every registered fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns every request received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// AddProject seeds a project, as if it had been created outside Terraform.
+func (s *Server) AddProject(key, name string) *Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addProject(key, name)
+}
+
+// Project returns the stored project with the given ID, or nil.
+func (s *Server) Project(id string) *Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.projects[id]
+}
+
+// Attachment returns the content of an uploaded file, or nil.
+func (s *Server) Attachment(id string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attachments[id]
+}
+
+// DeleteProject removes a project, as if it had been deleted outside
+// Terraform.
+func (s *Server) DeleteProject(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.projects, id)
+}
+
+// AddRepository seeds a repository on the main branch, as if it had been
+// created outside Terraform.
+func (s *Server) AddRepository(projectID, name string) *Repository {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	repo := &Repository{ID: s.newID(), Name: name, DefaultBranch: "main"}
+	s.projects[projectID].Repos[name] = repo
+	return repo
+}
+
+// AddRole seeds a custom role with the given key, as if it had been created
+// outside Terraform.
+func (s *Server) AddRole(projectID, key string, permissions ...string) *Role {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	role := &Role{ID: s.newID(), Key: key, Name: key, Permissions: permissions}
+	s.projects[projectID].Roles[role.ID] = role
+	return role
+}
+
+// AddAutomationJob registers an automation job and returns its ID.
+func (s *Server) AddAutomationJob(projectID, repo, branch, name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.newID()
+	s.jobs[id] = &AutomationJob{ID: id, ProjectID: projectID, Repo: repo, Branch: branch, Name: name}
+	return id
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("fake%06d", s.nextID)
+}
+
+func (s *Server) addProject(key, name string) *Project {
+	p := &Project{
+		ID:        s.newID(),
+		Key:       key,
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+		Members:   map[string]map[string]bool{},
+		Teams:     map[string]map[string]bool{},
+		Repos:     map[string]*Repository{},
+		Roles:     map[string]*Role{},
+	}
+	s.projects[p.ID] = p
+	return p
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: string(body)})
+	fault := s.matchFault(r)
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault.Status != 0 {
+			if fault.RetryAfter != "" {
+				w.Header().Set("Retry-After", fault.RetryAfter)
+			}
+			writeError(w, fault.Status, "InjectedFault", "fault injected by spacetest")
+			return
+		}
+	}
+
+	if r.URL.Path == "/oauth/token" {
+		s.serveToken(w, r)
+		return
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "invalid_token", "Access token is missing or invalid")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.route(w, r, body)
+}
+
+// matchFault returns the first fault applying to r and consumes one of its
+// uses. Callers must hold s.mu.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if f.Path != "" && !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if personalToken(r) {
+		return true
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accessTokens[token]
+}
+
+// RevokeAccessTokens invalidates every access token issued so far, to test
+// refreshing on 401.
+func (s *Server) RevokeAccessTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accessTokens = map[string]bool{}
+}
+
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if r.Method != http.MethodPost || !ok || id != ClientID || secret != ClientSecret {
+		writeError(w, http.StatusUnauthorized, "invalid_client", "Client authentication failed")
+		return
+	}
+
+	s.mu.Lock()
+	token := "access-" + s.newID()
+	s.accessTokens[token] = true
+	s.mu.Unlock()
+
+	writeJSON(w, map[string]interface{}{
+		"token_type":   "Bearer",
+		"access_token": token,
+		"expires_in":   600,
+	})
+}
+
+// personalToken reports whether r authenticates with Token rather than an
+// application's access token.
+func personalToken(r *http.Request) bool {
+	return r.Header.Get("Authorization") == "Bearer "+Token
+}
+
+// route dispatches an authenticated API request. Callers must hold s.mu.
+func (s *Server) route(w http.ResponseWriter, r *http.Request, body []byte) {
+	if r.URL.Path == "/api/http/uploads" && r.Method == http.MethodPost {
+		writeJSON(w, "/uploads/"+s.newID())
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, "/uploads/") && r.Method == http.MethodPut {
+		id := s.newID()
+		s.attachments[id] = body
+		_, _ = io.WriteString(w, id)
+		return
+	}
+
+	if r.URL.Path == "/api/http/team-directory/profiles/me" && r.Method == http.MethodGet {
+		// Applications authenticate with access tokens and have no profile.
+		if !personalToken(r) {
+			writeError(w, http.StatusForbidden, "PermissionDenied", "Not a profile")
+			return
+		}
+		writeJSON(w, map[string]string{"username": Username})
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, projectsPath) {
+		writeError(w, http.StatusNotFound, "NotFound", "Unknown endpoint "+r.URL.Path)
+		return
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, projectsPath), "/"), "/")
+	if parts[0] == "" {
+		parts = nil
+	}
+
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.listProjects(w, r)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.createProject(w, r, body)
+	case len(parts) == 3 && parts[0] == "automation" && parts[1] == "jobs" && r.Method == http.MethodGet:
+		s.getJob(w, parts[2])
+	case len(parts) >= 1:
+		p := s.lookupProject(parts[0])
+		if p == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project "+parts[0]+" not found")
+			return
+		}
+		s.routeProject(w, r, p, parts[1:], body)
+	default:
+		writeError(w, http.StatusNotFound, "NotFound", "Unknown endpoint "+r.URL.Path)
+	}
+}
+
+func (s *Server) routeProject(w http.ResponseWriter, r *http.Request, p *Project, parts []string, body []byte) {
+	// Like Space, an archived project is read-only until it is unarchived.
+	if p.Archived && archivedReadOnly(r.Method, parts) {
+		writeError(w, http.StatusBadRequest, "ValidationError", "Project "+p.Key+" is archived")
+		return
+	}
+
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, projectJSON(p))
+	case len(parts) == 0 && r.Method == http.MethodPatch:
+		s.updateProject(w, p, body)
+	case len(parts) == 0 && r.Method == http.MethodDelete:
+		delete(s.projects, p.ID)
+		w.WriteHeader(http.StatusOK)
+	case len(parts) == 1 && parts[0] == "archive" && r.Method == http.MethodPost:
+		p.Archived = true
+		w.WriteHeader(http.StatusOK)
+	case len(parts) == 1 && parts[0] == "unarchive" && r.Method == http.MethodPost:
+		p.Archived = false
+		w.WriteHeader(http.StatusOK)
+	case len(parts) == 3 && parts[0] == "people" && parts[2] == "update" && r.Method == http.MethodPost:
+		s.updatePeople(w, p, parts[1], body)
+	case len(parts) == 2 && parts[0] == "automation" && parts[1] == "jobs" && r.Method == http.MethodGet:
+		s.listJobs(w, r, p)
+	case len(parts) >= 2 && parts[0] == "repositories":
+		s.routeRepository(w, r, p, parts[1], parts[2:], body)
+	case len(parts) == 2 && parts[0] == "access" && parts[1] == "people" && r.Method == http.MethodGet:
+		writeJSON(w, peopleJSON(p))
+	case len(parts) >= 2 && parts[0] == "access" && parts[1] == "roles":
+		s.routeRole(w, r, p, parts[2:], body)
+	default:
+		writeError(w, http.StatusNotFound, "NotFound", "Unknown endpoint "+r.URL.Path)
+	}
+}
+
+// archivedReadOnly reports whether a request changes the project itself or
+// its memberships, which an archived project refuses.
+func archivedReadOnly(method string, parts []string) bool {
+	switch {
+	case len(parts) == 0:
+		return method == http.MethodPatch
+	case len(parts) == 3 && parts[0] == "people":
+		return true
+	}
+	return false
+}
+
+func (s *Server) routeRepository(w http.ResponseWriter, r *http.Request, p *Project, name string, parts []string, body []byte) {
+	repo := p.Repos[name]
+	if len(parts) == 0 && r.Method == http.MethodPost {
+		s.createRepository(w, p, name, body)
+		return
+	}
+	if repo == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "Repository "+name+" not found")
+		return
+	}
+
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, repositoryJSON(repo))
+	case len(parts) == 1 && parts[0] == "url" && r.Method == http.MethodGet:
+		host := strings.TrimPrefix(s.URL, "http://")
+		writeJSON(w, map[string]string{
+			"httpUrl": fmt.Sprintf("%s/git/%s/%s.git", s.URL, p.Key, repo.Name),
+			"sshUrl":  fmt.Sprintf("ssh://git@%s/%s/%s.git", host, p.Key, repo.Name),
+		})
+	case len(parts) == 0 && r.Method == http.MethodDelete:
+		delete(p.Repos, name)
+		w.WriteHeader(http.StatusOK)
+	case len(parts) == 1 && parts[0] == "settings" && r.Method == http.MethodGet:
+		branches := repo.ProtectedBranches
+		if branches == nil {
+			branches = json.RawMessage("[]")
+		}
+		writeJSON(w, map[string]interface{}{"protectedBranches": branches})
+	case len(parts) == 1 && parts[0] == "settings" && r.Method == http.MethodPost:
+		var req struct {
+			Settings struct {
+				ProtectedBranches json.RawMessage `json:"protectedBranches"`
+			} `json:"settings"`
+		}
+		if !decode(w, body, &req) {
+			return
+		}
+		repo.ProtectedBranches = req.Settings.ProtectedBranches
+		if string(repo.ProtectedBranches) == "null" {
+			repo.ProtectedBranches = nil
+		}
+		w.WriteHeader(http.StatusOK)
+	case len(parts) == 1 && parts[0] == "description" && r.Method == http.MethodPost:
+		var req struct {
+			Description string `json:"description"`
+		}
+		if !decode(w, body, &req) {
+			return
+		}
+		repo.Description = req.Description
+		w.WriteHeader(http.StatusOK)
+	case len(parts) == 1 && parts[0] == "default-branch" && r.Method == http.MethodPost:
+		var req struct {
+			Branch string `json:"branch"`
+		}
+		if !decode(w, body, &req) {
+			return
+		}
+		repo.DefaultBranch = req.Branch
+		p.LatestRepositoryActivity = time.Now().UTC()
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusNotFound, "NotFound", "Unknown endpoint "+r.URL.Path)
+	}
+}
+
+func (s *Server) routeRole(w http.ResponseWriter, r *http.Request, p *Project, parts []string, body []byte) {
+	var req struct {
+		Name        *string  `json:"name"`
+		Permissions []string `json:"permissions"`
+	}
+	if len(parts) == 0 && r.Method == http.MethodPost {
+		if !decode(w, body, &req) {
+			return
+		}
+		if req.Name == nil || *req.Name == "" {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Missing role name")
+			return
+		}
+		key := strings.ToLower(strings.ReplaceAll(*req.Name, " ", "-"))
+		if key == "admin" || key == "member" {
+			writeError(w, http.StatusConflict, "Conflict", "Role "+key+" already exists")
+			return
+		}
+		for _, role := range p.Roles {
+			if role.Key == key {
+				writeError(w, http.StatusConflict, "Conflict", "Role "+key+" already exists")
+				return
+			}
+		}
+		role := &Role{ID: s.newID(), Key: key, Name: *req.Name, Permissions: req.Permissions}
+		p.Roles[role.ID] = role
+		writeJSON(w, roleJSON(role))
+		return
+	}
+	if len(parts) != 1 {
+		writeError(w, http.StatusNotFound, "NotFound", "Unknown endpoint "+r.URL.Path)
+		return
+	}
+	role := p.Roles[strings.TrimPrefix(parts[0], "id:")]
+	if role == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "Role "+parts[0]+" not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, roleJSON(role))
+	case http.MethodPatch:
+		if !decode(w, body, &req) {
+			return
+		}
+		if req.Name != nil {
+			role.Name = *req.Name
+		}
+		if req.Permissions != nil {
+			role.Permissions = req.Permissions
+		}
+		writeJSON(w, roleJSON(role))
+	case http.MethodDelete:
+		delete(p.Roles, role.ID)
+		for _, holders := range []map[string]map[string]bool{p.Members, p.Teams} {
+			for name, roles := range holders {
+				delete(roles, role.Key)
+				if len(roles) == 0 {
+					delete(holders, name)
+				}
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusNotFound, "NotFound", "Unknown endpoint "+r.URL.Path)
+	}
+}
+
+// lookupProject resolves an id: or key: identifier. Callers must hold s.mu.
+func (s *Server) lookupProject(identifier string) *Project {
+	switch {
+	case strings.HasPrefix(identifier, "id:"):
+		return s.projects[strings.TrimPrefix(identifier, "id:")]
+	case strings.HasPrefix(identifier, "key:"):
+		key := strings.TrimPrefix(identifier, "key:")
+		for _, p := range s.projects {
+			if p.Key == key {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	term := strings.ToLower(r.URL.Query().Get("term"))
+	starred := r.URL.Query().Get("starred") == "true"
+	var all []interface{}
+	for _, p := range sortedProjects(s.projects) {
+		if term != "" && !strings.Contains(strings.ToLower(p.Name), term) && !strings.Contains(strings.ToLower(p.Key), term) {
+			continue
+		}
+		if starred && !p.Starred {
+			continue
+		}
+		all = append(all, projectJSON(p))
+	}
+	writeBatch(w, r, all)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req struct {
+		Key struct {
+			Key string `json:"key"`
+		} `json:"key"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Private     bool   `json:"private"`
+	}
+	if !decode(w, body, &req) {
+		return
+	}
+	for _, p := range s.projects {
+		if p.Key == req.Key.Key || p.Name == req.Name {
+			writeError(w, http.StatusConflict, "Conflict", "Project with this key or name already exists")
+			return
+		}
+	}
+	p := s.addProject(req.Key.Key, req.Name)
+	p.Description = req.Description
+	p.Private = req.Private
+	if personalToken(r) {
+		p.Members[Username] = map[string]bool{"admin": true}
+	}
+	writeJSON(w, projectJSON(p))
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, p *Project, body []byte) {
+	var req map[string]json.RawMessage
+	if !decode(w, body, &req) {
+		return
+	}
+	if v, ok := req["name"]; ok {
+		_ = json.Unmarshal(v, &p.Name)
+	}
+	if v, ok := req["key"]; ok {
+		var key struct {
+			Key string `json:"key"`
+		}
+		_ = json.Unmarshal(v, &key)
+		p.Key = key.Key
+	}
+	if v, ok := req["description"]; ok {
+		_ = json.Unmarshal(v, &p.Description)
+	}
+	if v, ok := req["private"]; ok {
+		_ = json.Unmarshal(v, &p.Private)
+	}
+	if v, ok := req["icon"]; ok {
+		p.Icon = ""
+		_ = json.Unmarshal(v, &p.Icon)
+	}
+	writeJSON(w, projectJSON(p))
+}
+
+func (s *Server) updatePeople(w http.ResponseWriter, p *Project, kind string, body []byte) {
+	var req struct {
+		Team        string   `json:"team"`
+		Profile     string   `json:"profile"`
+		AddRoles    []string `json:"addRoles"`
+		RemoveRoles []string `json:"removeRoles"`
+	}
+	if !decode(w, body, &req) {
+		return
+	}
+
+	var target map[string]map[string]bool
+	var name string
+	switch kind {
+	case "teams":
+		target, name = p.Teams, strings.TrimPrefix(req.Team, "name:")
+	case "members":
+		target, name = p.Members, strings.TrimPrefix(req.Profile, "username:")
+	default:
+		writeError(w, http.StatusNotFound, "NotFound", "Unknown people endpoint "+kind)
+		return
+	}
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "BadRequest", "Missing principal")
+		return
+	}
+
+	for _, role := range req.AddRoles {
+		if role != "" && !p.hasRole(role) {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Unknown role "+role)
+			return
+		}
+	}
+
+	roles := target[name]
+	if roles == nil {
+		roles = map[string]bool{}
+	}
+	for _, role := range req.AddRoles {
+		if role != "" {
+			roles[role] = true
+		}
+	}
+	for _, role := range req.RemoveRoles {
+		delete(roles, role)
+	}
+	if len(roles) == 0 {
+		delete(target, name)
+	} else {
+		target[name] = roles
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// hasRole reports whether key names a built-in or custom role of p.
+func (p *Project) hasRole(key string) bool {
+	if key == "admin" || key == "member" {
+		return true
+	}
+	for _, role := range p.Roles {
+		if role.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) createRepository(w http.ResponseWriter, p *Project, name string, body []byte) {
+	if _, exists := p.Repos[name]; exists {
+		writeError(w, http.StatusConflict, "Conflict", "Repository "+name+" already exists")
+		return
+	}
+	var req space.CreateRepositoryData
+	if !decode(w, body, &req) {
+		return
+	}
+	branch := req.DefaultBranch
+	if branch == "" {
+		branch = "main"
+	}
+	repo := &Repository{
+		ID:            s.newID(),
+		Name:          name,
+		Description:   req.Description,
+		DefaultBranch: branch,
+	}
+	p.Repos[name] = repo
+	p.LatestRepositoryActivity = time.Now().UTC()
+	writeJSON(w, repositoryJSON(repo))
+}
+
+func (s *Server) getJob(w http.ResponseWriter, id string) {
+	job := s.jobs[id]
+	if job == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "Job "+id+" not found")
+		return
+	}
+	writeJSON(w, jobJSON(job))
+}
+
+func (s *Server) listJobs(w http.ResponseWriter, r *http.Request, p *Project) {
+	repo := r.URL.Query().Get("repoFilter")
+	branch := r.URL.Query().Get("branchFilter")
+	var all []interface{}
+	for _, job := range sortedJobs(s.jobs) {
+		if job.ProjectID != p.ID || (repo != "" && job.Repo != repo) || (branch != "" && job.Branch != branch) {
+			continue
+		}
+		all = append(all, jobJSON(job))
+	}
+	writeBatch(w, r, all)
+}
+
+func projectJSON(p *Project) map[string]interface{} {
+	var members, admins, memberTeams, adminTeams, repos []interface{}
+	for _, username := range sortedKeys(p.Members) {
+		if p.Members[username]["member"] {
+			members = append(members, map[string]interface{}{"profile": map[string]string{"username": username}})
+		}
+		if p.Members[username]["admin"] {
+			admins = append(admins, map[string]string{"username": username})
+		}
+	}
+	for _, team := range sortedKeys(p.Teams) {
+		if p.Teams[team]["member"] {
+			memberTeams = append(memberTeams, map[string]string{"name": team})
+		}
+		if p.Teams[team]["admin"] {
+			adminTeams = append(adminTeams, map[string]string{"name": team})
+		}
+	}
+	for _, name := range sortedKeys(p.Repos) {
+		repos = append(repos, repositoryJSON(p.Repos[name]))
+	}
+
+	var icon interface{}
+	if p.Icon != "" {
+		icon = p.Icon
+	}
+	var latestActivity interface{}
+	if !p.LatestRepositoryActivity.IsZero() {
+		latestActivity = map[string]interface{}{
+			"iso":       p.LatestRepositoryActivity.Format(time.RFC3339),
+			"timestamp": p.LatestRepositoryActivity.UnixMilli(),
+		}
+	}
+
+	return map[string]interface{}{
+		"id":          p.ID,
+		"key":         map[string]string{"key": p.Key},
+		"name":        p.Name,
+		"description": p.Description,
+		"private":     p.Private,
+		"archived":    p.Archived,
+		"icon":        icon,
+		"createdAt": map[string]interface{}{
+			"iso":       p.CreatedAt.Format(time.RFC3339),
+			"timestamp": p.CreatedAt.UnixMilli(),
+		},
+		"latestRepositoryActivity": latestActivity,
+		"memberTeams":              memberTeams,
+		"members":                  members,
+		"adminTeams":               adminTeams,
+		"adminProfiles":            admins,
+		"repos":                    repos,
+	}
+}
+
+func peopleJSON(p *Project) map[string]interface{} {
+	profiles, teams := []interface{}{}, []interface{}{}
+	for _, username := range sortedKeys(p.Members) {
+		profiles = append(profiles, map[string]interface{}{
+			"profile": map[string]string{"username": username},
+			"roles":   roleKeysJSON(p.Members[username]),
+		})
+	}
+	for _, team := range sortedKeys(p.Teams) {
+		teams = append(teams, map[string]interface{}{
+			"team":  map[string]string{"name": team},
+			"roles": roleKeysJSON(p.Teams[team]),
+		})
+	}
+	return map[string]interface{}{"profiles": profiles, "teams": teams}
+}
+
+func roleKeysJSON(roles map[string]bool) []interface{} {
+	var out []interface{}
+	for _, key := range sortedKeys(roles) {
+		out = append(out, map[string]string{"key": key})
+	}
+	return out
+}
+
+func roleJSON(role *Role) map[string]interface{} {
+	permissions := role.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return map[string]interface{}{
+		"id":          role.ID,
+		"key":         role.Key,
+		"name":        role.Name,
+		"permissions": permissions,
+	}
+}
+
+func repositoryJSON(repo *Repository) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          repo.ID,
+		"name":        repo.Name,
+		"description": repo.Description,
+		"state":       "Ready",
+		"defaultBranch": map[string]string{
+			"head": "refs/heads/" + repo.DefaultBranch,
+			"ref":  "0000000000000000000000000000000000000000",
+		},
+	}
+}
+
+func jobJSON(job *AutomationJob) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       job.ID,
+		"name":     job.Name,
+		"repoName": job.Repo,
+	}
+}
+
+// writeBatch pages items according to the request's $skip and $top.
+func writeBatch(w http.ResponseWriter, r *http.Request, items []interface{}) {
+	skip, _ := strconv.Atoi(r.URL.Query().Get("$skip"))
+	top, err := strconv.Atoi(r.URL.Query().Get("$top"))
+	if err != nil || top <= 0 {
+		top = 100
+	}
+	if skip > len(items) {
+		skip = len(items)
+	}
+	end := skip + top
+	if end > len(items) {
+		end = len(items)
+	}
+	page := items[skip:end]
+	if page == nil {
+		page = []interface{}{}
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"next":       strconv.Itoa(end),
+		"totalCount": len(items),
+		"data":       page,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+func decode(w http.ResponseWriter, body []byte, v interface{}) bool {
+	if err := json.Unmarshal(body, v); err != nil {
+		writeError(w, http.StatusBadRequest, "BadRequest", "Malformed JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedProjects(projects map[string]*Project) []*Project {
+	var out []*Project
+	for _, id := range sortedKeys(projects) {
+		out = append(out, projects[id])
+	}
+	return out
+}
+
+func sortedJobs(jobs map[string]*AutomationJob) []*AutomationJob {
+	var out []*AutomationJob
+	for _, id := range sortedKeys(jobs) {
+		out = append(out, jobs[id])
+	}
+	return out
+}